@@ -21,6 +21,8 @@ import (
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
+const componentName = "aws/aws-sdk-go-v2/aws"
+
 const (
 	tagAWSAgent     = "aws.agent"
 	tagAWSService   = "aws.service"
@@ -33,6 +35,11 @@ type spanTimestampKey struct{}
 
 // AppendMiddleware takes the aws.Config and adds the Datadog tracing middleware into the APIOptions middleware stack.
 // See https://aws.github.io/aws-sdk-go-v2/docs/middleware for more information.
+//
+// The resource names of SQS, SNS, DynamoDB and S3 requests include the queue, topic, table or bucket
+// they target. The trace context is injected into the "_datadog" message attribute of the messages
+// sent with SQS SendMessage and SendMessageBatch and SNS Publish, unless they already have the maximum
+// number of attributes allowed.
 func AppendMiddleware(awsCfg *aws.Config, opts ...Option) {
 	cfg := &config{}
 
@@ -69,20 +76,30 @@ func (mw *traceMiddleware) startTraceMiddleware(stack *middleware.Stack) error {
 	) {
 		operation := awsmiddleware.GetOperationName(ctx)
 		serviceID := awsmiddleware.GetServiceID(ctx)
+		resource := fmt.Sprintf("%s.%s", serviceID, operation)
+		details, detailTags := resourceDetails(in.Parameters)
+		if details != "" {
+			resource += " " + details
+		}
 
 		opts := []ddtrace.StartSpanOption{
 			tracer.SpanType(ext.SpanTypeHTTP),
 			tracer.ServiceName(serviceName(mw.cfg, serviceID)),
-			tracer.ResourceName(fmt.Sprintf("%s.%s", serviceID, operation)),
+			tracer.ResourceName(resource),
 			tracer.Tag(tagAWSRegion, awsmiddleware.GetRegion(ctx)),
 			tracer.Tag(tagAWSOperation, operation),
 			tracer.Tag(tagAWSService, serviceID),
+			tracer.Tag(ext.Component, componentName),
 			tracer.StartTime(ctx.Value(spanTimestampKey{}).(time.Time)),
 		}
 		if !math.IsNaN(mw.cfg.analyticsRate) {
 			opts = append(opts, tracer.Tag(ext.EventSampleRate, mw.cfg.analyticsRate))
 		}
+		for k, v := range detailTags {
+			opts = append(opts, tracer.Tag(k, v))
+		}
 		span, spanctx := tracer.StartSpanFromContext(ctx, fmt.Sprintf("%s.request", serviceID), opts...)
+		injectTraceContext(in.Parameters, span)
 
 		// Handle initialize and continue through the middleware chain.
 		out, metadata, err = next.HandleInitialize(spanctx, in)
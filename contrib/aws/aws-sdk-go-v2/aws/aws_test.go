@@ -7,16 +7,24 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAppendMiddleware(t *testing.T) {
@@ -197,3 +205,167 @@ func TestAppendMiddleware_WithOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceDetails(t *testing.T) {
+	tests := []struct {
+		name             string
+		params           interface{}
+		expectedResource string
+		expectedTags     map[string]string
+	}{
+		{
+			name:             "sqs queue url",
+			params:           &sqs.SendMessageInput{QueueUrl: aws.String("https://sqs.eu-west-1.amazonaws.com/123456789012/my-queue")},
+			expectedResource: "my-queue",
+			expectedTags:     map[string]string{tagSQSQueueName: "my-queue"},
+		},
+		{
+			name:             "sqs queue name",
+			params:           &sqs.CreateQueueInput{QueueName: aws.String("my-queue")},
+			expectedResource: "my-queue",
+			expectedTags:     map[string]string{tagSQSQueueName: "my-queue"},
+		},
+		{
+			name:             "sns topic",
+			params:           &sns.PublishInput{TopicArn: aws.String("arn:aws:sns:eu-west-1:123456789012:my-topic")},
+			expectedResource: "my-topic",
+			expectedTags:     map[string]string{tagSNSTopicName: "my-topic"},
+		},
+		{
+			name:             "sns target topic",
+			params:           &sns.PublishInput{TargetArn: aws.String("arn:aws:sns:eu-west-1:123456789012:my-topic")},
+			expectedResource: "my-topic",
+			expectedTags:     map[string]string{tagSNSTopicName: "my-topic"},
+		},
+		{
+			name:   "sns target endpoint",
+			params: &sns.PublishInput{TargetArn: aws.String("arn:aws:sns:eu-west-1:123456789012:endpoint/APNS/my-app/0b2c1a8e-5b3e-3c0a-9f0e-6f3c2d1b0a9e")},
+		},
+		{
+			name:             "dynamodb table",
+			params:           &dynamodb.GetItemInput{TableName: aws.String("my-table")},
+			expectedResource: "my-table",
+			expectedTags:     map[string]string{tagDynamoDBTableName: "my-table"},
+		},
+		{
+			name:             "s3 object",
+			params:           &s3.GetObjectInput{Bucket: aws.String("my-bucket"), Key: aws.String("path/to/key")},
+			expectedResource: "my-bucket",
+			expectedTags:     map[string]string{tagS3BucketName: "my-bucket", tagS3ObjectKey: "path/to/key"},
+		},
+		{
+			name:   "missing value",
+			params: &dynamodb.GetItemInput{},
+		},
+		{
+			name:   "unknown operation",
+			params: &sqs.ListQueuesInput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, tags := resourceDetails(tt.params)
+			assert.Equal(t, tt.expectedResource, resource)
+			assert.Equal(t, tt.expectedTags, tags)
+		})
+	}
+}
+
+func TestAppendMiddleware_ResourceDetails(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	server := mockAWS(200)
+	defer server.Close()
+
+	resolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+		return aws.Endpoint{
+			PartitionID:   "aws",
+			URL:           server.URL,
+			SigningRegion: "eu-west-1",
+		}, nil
+	})
+
+	awsCfg := aws.Config{
+		Region:           "eu-west-1",
+		Credentials:      aws.AnonymousCredentials{},
+		EndpointResolver: resolver,
+	}
+
+	AppendMiddleware(&awsCfg)
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(server.URL + "/123456789012/my-queue"),
+		MessageBody: aws.String("body"),
+	}
+	sqsClient := sqs.NewFromConfig(awsCfg)
+	sqsClient.SendMessage(context.Background(), input)
+
+	spans := mt.FinishedSpans()
+	require.Len(t, spans, 1)
+	s := spans[0]
+	assert.Equal(t, "SQS.SendMessage my-queue", s.Tag(ext.ResourceName))
+	assert.Equal(t, "my-queue", s.Tag(tagSQSQueueName))
+
+	// the trace context was injected into the message attributes
+	attr, ok := input.MessageAttributes[datadogAttributeKey]
+	require.True(t, ok)
+	var carrier tracer.TextMapCarrier
+	require.NoError(t, json.Unmarshal([]byte(aws.ToString(attr.StringValue)), &carrier))
+	sctx, err := tracer.Extract(carrier)
+	require.NoError(t, err)
+	assert.Equal(t, s.SpanID(), sctx.SpanID())
+}
+
+func TestInjectTraceContext(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+	span := tracer.StartSpan("test")
+	defer span.Finish()
+
+	t.Run("batch", func(t *testing.T) {
+		input := &sqs.SendMessageBatchInput{Entries: []sqstypes.SendMessageBatchRequestEntry{{}, {}}}
+		injectTraceContext(input, span)
+		for _, e := range input.Entries {
+			assert.Contains(t, e.MessageAttributes, datadogAttributeKey)
+		}
+	})
+
+	t.Run("sns", func(t *testing.T) {
+		input := &sns.PublishInput{}
+		injectTraceContext(input, span)
+		assert.Contains(t, input.MessageAttributes, datadogAttributeKey)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		attrs := map[string]sqstypes.MessageAttributeValue{
+			"a": {DataType: aws.String("String"), StringValue: aws.String("v")},
+		}
+		input := &sqs.SendMessageInput{MessageAttributes: attrs}
+		injectTraceContext(input, span)
+		assert.Contains(t, input.MessageAttributes, "a")
+		assert.Contains(t, input.MessageAttributes, datadogAttributeKey)
+		// the caller's map is left unchanged
+		assert.Len(t, attrs, 1)
+		assert.NotContains(t, attrs, datadogAttributeKey)
+
+		snsAttrs := map[string]snstypes.MessageAttributeValue{
+			"a": {DataType: aws.String("String"), StringValue: aws.String("v")},
+		}
+		snsInput := &sns.PublishInput{MessageAttributes: snsAttrs}
+		injectTraceContext(snsInput, span)
+		assert.Contains(t, snsInput.MessageAttributes, datadogAttributeKey)
+		assert.NotContains(t, snsAttrs, datadogAttributeKey)
+	})
+
+	t.Run("max-attributes", func(t *testing.T) {
+		attrs := make(map[string]sqstypes.MessageAttributeValue, maxMessageAttributes)
+		for i := 0; i < maxMessageAttributes; i++ {
+			attrs[string(rune('a'+i))] = sqstypes.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("v")}
+		}
+		input := &sqs.SendMessageInput{MessageAttributes: attrs}
+		injectTraceContext(input, span)
+		assert.Len(t, input.MessageAttributes, maxMessageAttributes)
+		assert.NotContains(t, input.MessageAttributes, datadogAttributeKey)
+	})
+}
@@ -0,0 +1,218 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+package aws
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	tagSQSQueueName      = "aws.sqs.queue_name"
+	tagSNSTopicName      = "aws.sns.topic_name"
+	tagDynamoDBTableName = "aws.dynamodb.table_name"
+	tagS3BucketName      = "aws.s3.bucket_name"
+	tagS3ObjectKey       = "aws.s3.object_key"
+)
+
+const (
+	// datadogAttributeKey is the name of the message attribute used to propagate the
+	// trace context through SQS and SNS.
+	datadogAttributeKey = "_datadog"
+	// maxMessageAttributes is the maximum number of attributes allowed on a SQS or
+	// SNS message. No trace context is injected into messages which already have
+	// that many attributes.
+	maxMessageAttributes = 10
+)
+
+// resourceDetails returns the service specific resource of the request having the given
+// parameters, if any, e.g. the name of the queue for SQS or of the table for DynamoDB.
+// Any further details are returned as tags.
+func resourceDetails(params interface{}) (resource string, tags map[string]string) {
+	switch p := params.(type) {
+	// SQS
+	case *sqs.SendMessageInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.SendMessageBatchInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.ReceiveMessageInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.DeleteMessageInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.DeleteMessageBatchInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.ChangeMessageVisibilityInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.GetQueueAttributesInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.PurgeQueueInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.DeleteQueueInput:
+		return sqsQueueDetails(p.QueueUrl)
+	case *sqs.CreateQueueInput:
+		return detail(tagSQSQueueName, aws.ToString(p.QueueName))
+	case *sqs.GetQueueUrlInput:
+		return detail(tagSQSQueueName, aws.ToString(p.QueueName))
+	// SNS
+	case *sns.PublishInput:
+		if p.TopicArn != nil {
+			return snsTopicDetails(p.TopicArn)
+		}
+		// TargetArn may also hold a platform endpoint ARN, which identifies a device
+		if a := aws.ToString(p.TargetArn); !strings.HasPrefix(a[strings.LastIndex(a, ":")+1:], "endpoint/") {
+			return snsTopicDetails(p.TargetArn)
+		}
+	// DynamoDB
+	case *dynamodb.GetItemInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.PutItemInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.UpdateItemInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.DeleteItemInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.QueryInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.ScanInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	case *dynamodb.DescribeTableInput:
+		return detail(tagDynamoDBTableName, aws.ToString(p.TableName))
+	// S3
+	case *s3.GetObjectInput:
+		return s3ObjectDetails(p.Bucket, p.Key)
+	case *s3.PutObjectInput:
+		return s3ObjectDetails(p.Bucket, p.Key)
+	case *s3.HeadObjectInput:
+		return s3ObjectDetails(p.Bucket, p.Key)
+	case *s3.DeleteObjectInput:
+		return s3ObjectDetails(p.Bucket, p.Key)
+	case *s3.CopyObjectInput:
+		return s3ObjectDetails(p.Bucket, p.Key)
+	case *s3.ListObjectsInput:
+		return detail(tagS3BucketName, aws.ToString(p.Bucket))
+	case *s3.ListObjectsV2Input:
+		return detail(tagS3BucketName, aws.ToString(p.Bucket))
+	case *s3.HeadBucketInput:
+		return detail(tagS3BucketName, aws.ToString(p.Bucket))
+	case *s3.CreateBucketInput:
+		return detail(tagS3BucketName, aws.ToString(p.Bucket))
+	case *s3.DeleteBucketInput:
+		return detail(tagS3BucketName, aws.ToString(p.Bucket))
+	}
+	return "", nil
+}
+
+// detail returns value as the resource, along with a tag holding it.
+func detail(tag, value string) (string, map[string]string) {
+	if value == "" {
+		return "", nil
+	}
+	return value, map[string]string{tag: value}
+}
+
+// sqsQueueDetails returns the name of the queue found at the end of the given queue URL.
+func sqsQueueDetails(queueURL *string) (string, map[string]string) {
+	u := aws.ToString(queueURL)
+	return detail(tagSQSQueueName, u[strings.LastIndex(u, "/")+1:])
+}
+
+// snsTopicDetails returns the name of the topic found at the end of the given ARN.
+func snsTopicDetails(arn *string) (string, map[string]string) {
+	a := aws.ToString(arn)
+	return detail(tagSNSTopicName, a[strings.LastIndex(a, ":")+1:])
+}
+
+// s3ObjectDetails returns the bucket as the resource. The object key is only set as a tag
+// as it would otherwise make the resource name high-cardinality.
+func s3ObjectDetails(bucket, key *string) (string, map[string]string) {
+	resource, tags := detail(tagS3BucketName, aws.ToString(bucket))
+	if k := aws.ToString(key); k != "" && tags != nil {
+		tags[tagS3ObjectKey] = k
+	}
+	return resource, tags
+}
+
+// injectTraceContext injects the context of span into the message attributes of the
+// SQS and SNS messages found in the given request parameters.
+func injectTraceContext(params interface{}, span ddtrace.Span) {
+	switch p := params.(type) {
+	case *sqs.SendMessageInput:
+		p.MessageAttributes = injectSQSAttributes(p.MessageAttributes, span)
+	case *sqs.SendMessageBatchInput:
+		for i := range p.Entries {
+			p.Entries[i].MessageAttributes = injectSQSAttributes(p.Entries[i].MessageAttributes, span)
+		}
+	case *sns.PublishInput:
+		p.MessageAttributes = injectSNSAttributes(p.MessageAttributes, span)
+	}
+}
+
+// encodeTraceContext returns the JSON encoded headers propagating the context of span.
+func encodeTraceContext(span ddtrace.Span) (string, bool) {
+	carrier := tracer.TextMapCarrier{}
+	if err := tracer.Inject(span.Context(), carrier); err != nil {
+		log.Debug("contrib/aws/aws-sdk-go-v2/aws: Failed to inject span context: %v", err)
+		return "", false
+	}
+	b, err := json.Marshal(carrier)
+	if err != nil {
+		log.Debug("contrib/aws/aws-sdk-go-v2/aws: Failed to encode span context: %v", err)
+		return "", false
+	}
+	return string(b), true
+}
+
+func injectSQSAttributes(attrs map[string]sqstypes.MessageAttributeValue, span ddtrace.Span) map[string]sqstypes.MessageAttributeValue {
+	if _, ok := attrs[datadogAttributeKey]; !ok && len(attrs) >= maxMessageAttributes {
+		return attrs
+	}
+	value, ok := encodeTraceContext(span)
+	if !ok {
+		return attrs
+	}
+	// the attributes are copied as they belong to the caller, and may be shared
+	// across concurrent requests
+	clone := make(map[string]sqstypes.MessageAttributeValue, len(attrs)+1)
+	for k, v := range attrs {
+		clone[k] = v
+	}
+	clone[datadogAttributeKey] = sqstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+	return clone
+}
+
+func injectSNSAttributes(attrs map[string]snstypes.MessageAttributeValue, span ddtrace.Span) map[string]snstypes.MessageAttributeValue {
+	if _, ok := attrs[datadogAttributeKey]; !ok && len(attrs) >= maxMessageAttributes {
+		return attrs
+	}
+	value, ok := encodeTraceContext(span)
+	if !ok {
+		return attrs
+	}
+	clone := make(map[string]snstypes.MessageAttributeValue, len(attrs)+1)
+	for k, v := range attrs {
+		clone[k] = v
+	}
+	clone[datadogAttributeKey] = snstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+	return clone
+}
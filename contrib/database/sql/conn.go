@@ -215,6 +215,7 @@ func (tp *traceParams) tryTrace(ctx context.Context, qtype queryType, query stri
 		tracer.ServiceName(tp.cfg.serviceName),
 		tracer.SpanType(ext.SpanTypeSQL),
 		tracer.StartTime(startTime),
+		tracer.Tag(ext.Component, componentName),
 	)
 	if !math.IsNaN(tp.cfg.analyticsRate) {
 		opts = append(opts, tracer.Tag(ext.EventSampleRate, tp.cfg.analyticsRate))
@@ -222,6 +223,10 @@ func (tp *traceParams) tryTrace(ctx context.Context, qtype queryType, query stri
 	span, _ := tracer.StartSpanFromContext(ctx, name, opts...)
 	resource := string(qtype)
 	if query != "" {
+		if pquery, processed := processQuery(tp.cfg, query); processed {
+			query = pquery
+			span.SetTag(ext.SQLQuery, query)
+		}
 		resource = query
 	}
 	span.SetTag("sql.query_type", string(qtype))
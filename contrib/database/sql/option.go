@@ -19,6 +19,7 @@ type config struct {
 	dsn                  string
 	childSpansOnly       bool
 	commentInjectionMode tracer.SQLCommentInjectionMode
+	sqlSanitization      bool
 }
 
 // Option represents an option that can be passed to Register, Open or OpenDB.
@@ -96,3 +97,14 @@ func WithSQLCommentInjection(mode tracer.SQLCommentInjectionMode) Option {
 		cfg.commentInjectionMode = mode
 	}
 }
+
+// WithSQLSanitization enables client-side sanitization of traced queries: all literals
+// (strings, numbers, etc.) are replaced with "?" before the query is used as the span
+// resource name and sql.query tag. This avoids high resource cardinality and prevents
+// sensitive values from leaving the application. Queries that can not be parsed are
+// replaced altogether. The query sent to the database is not modified.
+func WithSQLSanitization() Option {
+	return func(cfg *config) {
+		cfg.sqlSanitization = true
+	}
+}
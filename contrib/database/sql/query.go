@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+package sql
+
+import (
+	"sync"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+
+	"github.com/DataDog/datadog-agent/pkg/obfuscate"
+)
+
+// textNonParsable is used in place of queries which could not be sanitized, so that
+// their literals are never leaked.
+const textNonParsable = "Non-parsable SQL query"
+
+// queryProcessors holds the functions registered via RegisterQueryProcessor.
+var queryProcessors struct {
+	sync.RWMutex
+	fns []func(query string) string
+}
+
+// RegisterQueryProcessor registers fn to be applied to the queries of all traced
+// databases before they are used as span resource names and sql.query tags. Processors
+// are applied in the order in which they were registered and run before the
+// sanitization enabled by WithSQLSanitization. They must be safe for concurrent use.
+// The query sent to the database is not modified.
+func RegisterQueryProcessor(fn func(query string) string) {
+	queryProcessors.Lock()
+	defer queryProcessors.Unlock()
+	queryProcessors.fns = append(queryProcessors.fns, fn)
+}
+
+// unregisterQueryProcessors removes all registered query processors.
+func unregisterQueryProcessors() {
+	queryProcessors.Lock()
+	defer queryProcessors.Unlock()
+	queryProcessors.fns = nil
+}
+
+var (
+	obfuscatorOnce sync.Once
+	obfuscator     *obfuscate.Obfuscator
+)
+
+// sanitizeQuery replaces all literals found in query with "?".
+func sanitizeQuery(query string) string {
+	obfuscatorOnce.Do(func() {
+		obfuscator = obfuscate.NewObfuscator(obfuscate.Config{})
+	})
+	oq, err := obfuscator.ObfuscateSQLString(query)
+	if err != nil {
+		log.Debug("contrib/database/sql: failed to sanitize query: %v", err)
+		return textNonParsable
+	}
+	return oq.Query
+}
+
+// processQuery applies all registered query processors to query, followed by the
+// built-in sanitization if it is enabled in cfg. It returns the processed query
+// and whether any processing took place.
+func processQuery(cfg *config, query string) (string, bool) {
+	queryProcessors.RLock()
+	fns := queryProcessors.fns
+	queryProcessors.RUnlock()
+	if len(fns) == 0 && !cfg.sqlSanitization {
+		return query, false
+	}
+	for _, fn := range fns {
+		query = fn(query)
+	}
+	if cfg.sqlSanitization {
+		query = sanitizeQuery(query)
+	}
+	return query, true
+}
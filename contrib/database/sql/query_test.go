@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+package sql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/database/sql/internal"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+)
+
+func TestQueryProcessing(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id = 42 AND name = 'bob'"
+
+	testCases := []struct {
+		name       string
+		opts       []RegisterOption
+		processors []func(string) string
+		expected   string // expected resource name
+		tagged     bool   // whether the sql.query tag is expected
+	}{
+		{
+			name:     "disabled",
+			expected: query,
+		},
+		{
+			name:     "sanitization",
+			opts:     []RegisterOption{WithSQLSanitization()},
+			expected: "SELECT * FROM users WHERE id = ? AND name = ?",
+			tagged:   true,
+		},
+		{
+			name:       "processor",
+			processors: []func(string) string{strings.ToLower},
+			expected:   "select * from users where id = 42 and name = 'bob'",
+			tagged:     true,
+		},
+		{
+			name: "processors-and-sanitization",
+			opts: []RegisterOption{WithSQLSanitization()},
+			processors: []func(string) string{
+				func(q string) string { return strings.Replace(q, "users", "people", 1) },
+				strings.ToLower,
+			},
+			expected: "select * from people where id = ? and name = ?",
+			tagged:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mt := mocktracer.Start()
+			defer mt.Stop()
+			for _, fn := range tc.processors {
+				RegisterQueryProcessor(fn)
+			}
+			defer unregisterQueryProcessors()
+
+			d := &internal.MockDriver{}
+			Register("test", d, tc.opts...)
+			defer unregister("test")
+
+			db, err := Open("test", "dn")
+			require.NoError(t, err)
+			_, err = db.ExecContext(context.Background(), query)
+			require.NoError(t, err)
+
+			// the query sent to the database is never modified
+			require.Len(t, d.Executed, 1)
+			assert.Equal(t, query, d.Executed[0])
+
+			var span mocktracer.Span
+			for _, s := range mt.FinishedSpans() {
+				if s.Tag("sql.query_type") == queryTypeExec {
+					span = s
+				}
+			}
+			require.NotNil(t, span)
+			assert.Equal(t, tc.expected, span.Tag(ext.ResourceName))
+			if tc.tagged {
+				assert.Equal(t, tc.expected, span.Tag(ext.SQLQuery))
+			} else {
+				assert.Nil(t, span.Tag(ext.SQLQuery))
+			}
+		})
+	}
+}
+
+func TestSanitizeQueryNonParsable(t *testing.T) {
+	assert.Equal(t, textNonParsable, sanitizeQuery("SELECT 'unterminated"))
+}
@@ -30,6 +30,8 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
 )
 
+const componentName = "database/sql"
+
 // registeredDrivers holds a registry of all drivers registered via the sqltrace package.
 var registeredDrivers = &driverRegistry{
 	keys:    make(map[reflect.Type]string),
@@ -191,6 +193,9 @@ func OpenDB(c driver.Connector, opts ...Option) *sql.DB {
 		cfg.commentInjectionMode = rc.commentInjectionMode
 	}
 	cfg.childSpansOnly = rc.childSpansOnly
+	if !cfg.sqlSanitization {
+		cfg.sqlSanitization = rc.sqlSanitization
+	}
 	tc := &tracedConnector{
 		connector:  c,
 		driverName: name,
@@ -19,6 +19,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const componentName = "gin-gonic/gin"
+
 // Middleware returns middleware that will trace incoming requests. If service is empty then the
 // default service name will be used.
 func Middleware(service string, opts ...Option) gin.HandlerFunc {
@@ -30,6 +32,7 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 	log.Debug("contrib/gin-gonic/gin: Configuring Middleware: Service: %s, %#v", cfg.serviceName, cfg)
 	spanOpts := []tracer.StartSpanOption{
 		tracer.ServiceName(cfg.serviceName),
+		tracer.Tag(ext.Component, componentName),
 	}
 	return func(c *gin.Context) {
 		if cfg.ignoreRequest(c) {
@@ -40,8 +43,12 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 			opts = append(opts, tracer.Tag(ext.EventSampleRate, cfg.analyticsRate))
 		}
 		opts = append(opts, tracer.Tag(ext.HTTPRoute, c.FullPath()))
+		if len(cfg.headerTags) > 0 {
+			opts = append(opts, httptrace.HeaderTagsFromRequest(c.Request, cfg.headerTags))
+		}
 		span, ctx := httptrace.StartRequestSpan(c.Request, opts...)
 		defer func() {
+			httptrace.SetResponseHeaderTags(span, c.Writer.Header(), cfg.headerTags)
 			httptrace.FinishRequestSpan(span, c.Writer.Status())
 		}()
 
@@ -661,3 +661,33 @@ func TestAppSec(t *testing.T) {
 		require.True(t, strings.Contains(event.(string), "crs-933-130"))
 	})
 }
+
+func TestHeaderTags(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	serve := func(opts ...Option) mocktracer.Span {
+		mt.Reset()
+		router := gin.New()
+		router.Use(Middleware("foobar", opts...))
+		router.GET("/", func(c *gin.Context) {
+			c.Header("X-Response-Id", "456")
+			c.String(200, "OK")
+		})
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("X-Request-Id", "123")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+		spans := mt.FinishedSpans()
+		require.Len(t, spans, 1)
+		return spans[0]
+	}
+
+	span := serve(WithHeaderTags([]string{"x-request-id:request_id", "x-response-id"}))
+	assert.Equal(t, "123", span.Tag("request_id"))
+	assert.Equal(t, "456", span.Tag("http.response.headers.x-response-id"))
+
+	// an empty list overrides the headers configured with DD_TRACE_HEADER_TAGS
+	withEnvHeaderTags := func(cfg *config) { cfg.headerTags = []string{"X-Request-Id"} }
+	assert.Equal(t, "123", serve(withEnvHeaderTags).Tag("http.request.headers.x-request-id"))
+	assert.Nil(t, serve(withEnvHeaderTags, WithHeaderTags([]string{})).Tag("http.request.headers.x-request-id"))
+}
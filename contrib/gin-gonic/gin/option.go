@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
 )
@@ -20,6 +21,7 @@ type config struct {
 	resourceNamer func(c *gin.Context) string
 	serviceName   string
 	ignoreRequest func(c *gin.Context) bool
+	headerTags    []string
 }
 
 func newConfig(service string) *config {
@@ -38,6 +40,7 @@ func newConfig(service string) *config {
 		resourceNamer: defaultResourceNamer,
 		serviceName:   service,
 		ignoreRequest: func(_ *gin.Context) bool { return false },
+		headerTags:    httptrace.HeaderTags(),
 	}
 }
 
@@ -96,3 +99,12 @@ func defaultResourceNamer(c *gin.Context) string {
 	}
 	return getName(c.Request, c)
 }
+
+// WithHeaderTags adds the values of the given request and response headers as span tags,
+// instead of the headers configured with DD_TRACE_HEADER_TAGS. Headers are given in the
+// same header[:tag_name] form.
+func WithHeaderTags(headers []string) Option {
+	return func(cfg *config) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
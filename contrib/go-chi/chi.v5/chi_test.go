@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	pappsec "gopkg.in/DataDog/dd-trace-go.v1/appsec"
@@ -411,3 +412,39 @@ func TestAppSec(t *testing.T) {
 		require.True(t, strings.Contains(event.(string), "crs-933-130"))
 	})
 }
+
+func TestHeaderTags(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	// the span options given to the middleware are shared by concurrent requests,
+	// which must not see each other's header tags
+	router := chi.NewRouter()
+	router.Use(Middleware(
+		WithSpanOptions(tracer.Tag("a", 1), tracer.Tag("b", 2)),
+		WithHeaderTags([]string{"x-request-id", "x-response-id:response_id"}),
+	))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Response-Id", r.Header.Get("X-Request-Id"))
+		w.Write([]byte("OK"))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("X-Request-Id", id)
+			router.ServeHTTP(httptest.NewRecorder(), r)
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	spans := mt.FinishedSpans()
+	assert.Len(t, spans, 10)
+	for _, s := range spans {
+		assert.Equal(t, 1, s.Tag("a"))
+		assert.Equal(t, s.Tag("http.request.headers.x-request-id"), s.Tag("response_id"))
+	}
+}
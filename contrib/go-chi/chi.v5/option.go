@@ -9,6 +9,7 @@ import (
 	"math"
 	"net/http"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
@@ -20,6 +21,7 @@ type config struct {
 	analyticsRate float64
 	isStatusError func(statusCode int) bool
 	ignoreRequest func(r *http.Request) bool
+	headerTags    []string
 }
 
 // Option represents an option that can be passed to NewRouter.
@@ -37,6 +39,7 @@ func defaults(cfg *config) {
 	}
 	cfg.isStatusError = isServerError
 	cfg.ignoreRequest = func(_ *http.Request) bool { return false }
+	cfg.headerTags = httptrace.HeaderTags()
 }
 
 // WithServiceName sets the given service name for the router.
@@ -96,3 +99,11 @@ func WithIgnoreRequest(fn func(r *http.Request) bool) Option {
 		cfg.ignoreRequest = fn
 	}
 }
+
+// WithHeaderTags sets the request and response headers to add as span tags, each given as
+// header[:tag_name]. It takes precedence over DD_TRACE_HEADER_TAGS.
+func WithHeaderTags(headers []string) Option {
+	return func(cfg *config) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/go-chi/chi/middleware"
 )
 
+const componentName = "go-chi/chi"
+
 // Middleware returns middleware that will trace incoming requests.
 func Middleware(opts ...Option) func(next http.Handler) http.Handler {
 	cfg := new(config)
@@ -29,20 +31,25 @@ func Middleware(opts ...Option) func(next http.Handler) http.Handler {
 		fn(cfg)
 	}
 	log.Debug("contrib/go-chi/chi: Configuring Middleware: %#v", cfg)
-	spanOpts := append(cfg.spanOpts, tracer.ServiceName(cfg.serviceName))
+	spanOpts := append(cfg.spanOpts, tracer.ServiceName(cfg.serviceName), tracer.Tag(ext.Component, componentName))
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if cfg.ignoreRequest(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
-			opts := spanOpts
+			// copy spanOpts, which is shared by all requests, before appending any per-request option
+			opts := append([]tracer.StartSpanOption(nil), spanOpts...)
 			if !math.IsNaN(cfg.analyticsRate) {
 				opts = append(opts, tracer.Tag(ext.EventSampleRate, cfg.analyticsRate))
 			}
+			if len(cfg.headerTags) > 0 {
+				opts = append(opts, httptrace.HeaderTagsFromRequest(r, cfg.headerTags))
+			}
 			span, ctx := httptrace.StartRequestSpan(r, opts...)
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 			defer func() {
+				httptrace.SetResponseHeaderTags(span, ww.Header(), cfg.headerTags)
 				status := ww.Status()
 				var opts []tracer.FinishOption
 				if cfg.isStatusError(status) {
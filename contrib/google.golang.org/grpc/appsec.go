@@ -18,10 +18,11 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 // UnaryHandler wrapper to use when AppSec is enabled to monitor its execution.
-func appsecUnaryHandlerMiddleware(span ddtrace.Span, handler grpc.UnaryHandler) grpc.UnaryHandler {
+func appsecUnaryHandlerMiddleware(span ddtrace.Span, handler grpc.UnaryHandler, cfg *config) grpc.UnaryHandler {
 	httpsec.SetAppSecTags(span)
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		md, _ := metadata.FromIncomingContext(ctx)
@@ -34,13 +35,16 @@ func appsecUnaryHandlerMiddleware(span ddtrace.Span, handler grpc.UnaryHandler)
 			}
 			setAppSecTags(ctx, span, events)
 		}()
-		defer grpcsec.StartReceiveOperation(grpcsec.ReceiveOperationArgs{}, op).Finish(grpcsec.ReceiveOperationRes{Message: req})
+		grpcsec.StartReceiveOperation(grpcsec.ReceiveOperationArgs{}, op).Finish(grpcsec.ReceiveOperationRes{Message: req})
+		if err := blockingError(span, op, cfg); err != nil {
+			return nil, err
+		}
 		return handler(ctx, req)
 	}
 }
 
 // StreamHandler wrapper to use when AppSec is enabled to monitor its execution.
-func appsecStreamHandlerMiddleware(span ddtrace.Span, handler grpc.StreamHandler) grpc.StreamHandler {
+func appsecStreamHandlerMiddleware(span ddtrace.Span, handler grpc.StreamHandler, cfg *config) grpc.StreamHandler {
 	httpsec.SetAppSecTags(span)
 	return func(srv interface{}, stream grpc.ServerStream) error {
 		md, _ := metadata.FromIncomingContext(stream.Context())
@@ -53,23 +57,43 @@ func appsecStreamHandlerMiddleware(span ddtrace.Span, handler grpc.StreamHandler
 			}
 			setAppSecTags(stream.Context(), span, events)
 		}()
-		return handler(srv, appsecServerStream{ServerStream: stream, handlerOperation: op})
+		// The metadata is monitored when the handler operation starts, which
+		// allows to abort the stream before it gets handled.
+		if err := blockingError(span, op, cfg); err != nil {
+			return err
+		}
+		return handler(srv, appsecServerStream{ServerStream: stream, handlerOperation: op, span: span, cfg: cfg})
 	}
 }
 
 type appsecServerStream struct {
 	grpc.ServerStream
 	handlerOperation *grpcsec.HandlerOperation
+	span             ddtrace.Span
+	cfg              *config
 }
 
 // RecvMsg implements grpc.ServerStream interface method to monitor its
 // execution with AppSec.
 func (ss appsecServerStream) RecvMsg(m interface{}) error {
 	op := grpcsec.StartReceiveOperation(grpcsec.ReceiveOperationArgs{}, ss.handlerOperation)
-	defer func() {
-		op.Finish(grpcsec.ReceiveOperationRes{Message: m})
-	}()
-	return ss.ServerStream.RecvMsg(m)
+	err := ss.ServerStream.RecvMsg(m)
+	op.Finish(grpcsec.ReceiveOperationRes{Message: m})
+	if err != nil {
+		return err
+	}
+	return blockingError(ss.span, ss.handlerOperation, ss.cfg)
+}
+
+// blockingError returns the gRPC status error to abort the RPC with when
+// blocking is enabled and an attack was detected by the handler operation so
+// far. It returns nil otherwise.
+func blockingError(span ddtrace.Span, op *grpcsec.HandlerOperation, cfg *config) error {
+	if !cfg.appsecBlocking || !op.AttackDetected() {
+		return nil
+	}
+	span.SetTag(tagAppSecBlocked, true)
+	return status.Error(cfg.appsecBlockingCode, "request blocked by appsec")
 }
 
 // Set the AppSec tags when security events were found.
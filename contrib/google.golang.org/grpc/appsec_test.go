@@ -7,6 +7,7 @@ package grpc
 
 import (
 	"context"
+	"os"
 	"strings"
 	"testing"
 
@@ -14,7 +15,9 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/appsec"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestAppSec(t *testing.T) {
@@ -94,3 +97,124 @@ func TestAppSec(t *testing.T) {
 		require.True(t, strings.Contains(event, "ua0-600-55x")) // canary rule attack attempt
 	})
 }
+
+func TestAppSecMessagesDisabled(t *testing.T) {
+	os.Setenv("DD_APPSEC_GRPC_MESSAGES_ENABLED", "false")
+	defer os.Unsetenv("DD_APPSEC_GRPC_MESSAGES_ENABLED")
+	appsec.Start()
+	defer appsec.Stop()
+	if !appsec.Enabled() {
+		t.Skip("appsec disabled")
+	}
+
+	rig, err := newRig(false)
+	require.NoError(t, err)
+	defer rig.Close()
+
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	// Send a XSS attack in the payload along with the canary value in the RPC metadata
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("dd-canary", "dd-test-scanner-log"))
+	res, err := rig.client.Ping(ctx, &FixtureRequest{Name: "<script>alert('xss');</script>"})
+	require.NoError(t, err)
+	require.Equal(t, "passed", res.Message)
+
+	finished := mt.FinishedSpans()
+	require.Len(t, finished, 1)
+
+	// Only the metadata should have been monitored
+	event, _ := finished[0].Tag("_dd.appsec.json").(string)
+	require.True(t, strings.Contains(event, "ua0-600-55x")) // canary rule attack attempt
+	require.False(t, strings.Contains(event, "crs-941-100"))
+}
+
+func TestAppSecBlocking(t *testing.T) {
+	appsec.Start()
+	defer appsec.Stop()
+	if !appsec.Enabled() {
+		t.Skip("appsec disabled")
+	}
+
+	rig, err := newRig(false, WithAppSecBlocking(codes.PermissionDenied))
+	require.NoError(t, err)
+	defer rig.Close()
+
+	client := rig.client
+
+	// serverSpan returns the finished grpc.server span.
+	serverSpan := func(t *testing.T, mt mocktracer.Tracer) mocktracer.Span {
+		for _, s := range mt.FinishedSpans() {
+			if s.OperationName() == "grpc.server" {
+				return s
+			}
+		}
+		require.FailNow(t, "grpc.server span not found")
+		return nil
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		mt := mocktracer.Start()
+		defer mt.Stop()
+
+		res, err := client.Ping(context.Background(), &FixtureRequest{Name: "<script>alert('xss');</script>"})
+		require.Nil(t, res)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		span := serverSpan(t, mt)
+		require.Equal(t, true, span.Tag(tagAppSecBlocked))
+		event, _ := span.Tag("_dd.appsec.json").(string)
+		require.True(t, strings.Contains(event, "crs-941-100")) // XSS attack attempt
+	})
+
+	t.Run("unary-no-attack", func(t *testing.T) {
+		mt := mocktracer.Start()
+		defer mt.Stop()
+
+		res, err := client.Ping(context.Background(), &FixtureRequest{Name: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, "passed", res.Message)
+		require.Nil(t, serverSpan(t, mt).Tag(tagAppSecBlocked))
+	})
+
+	t.Run("stream-metadata", func(t *testing.T) {
+		mt := mocktracer.Start()
+		defer mt.Stop()
+
+		// The canary value in the metadata is detected when the stream opens
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("dd-canary", "dd-test-scanner-log"))
+		stream, err := client.StreamPing(ctx)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		span := serverSpan(t, mt)
+		require.Equal(t, true, span.Tag(tagAppSecBlocked))
+		event, _ := span.Tag("_dd.appsec.json").(string)
+		require.True(t, strings.Contains(event, "ua0-600-55x")) // canary rule attack attempt
+	})
+
+	t.Run("stream-message", func(t *testing.T) {
+		mt := mocktracer.Start()
+		defer mt.Stop()
+
+		stream, err := client.StreamPing(context.Background())
+		require.NoError(t, err)
+
+		// A benign message goes through
+		require.NoError(t, stream.Send(&FixtureRequest{Name: "hello"}))
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "passed", res.Message)
+
+		// A SQLi attack aborts the stream
+		require.NoError(t, stream.Send(&FixtureRequest{Name: "something UNION SELECT * from users"}))
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		span := serverSpan(t, mt)
+		require.Equal(t, true, span.Tag(tagAppSecBlocked))
+		event, _ := span.Tag("_dd.appsec.json").(string)
+		require.True(t, strings.Contains(event, "crs-942-100")) // SQL-injection attack attempt
+	})
+}
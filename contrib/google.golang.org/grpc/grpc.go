@@ -22,6 +22,8 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const componentName = "google.golang.org/grpc"
+
 func startSpanFromContext(
 	ctx context.Context, method, operation, service string, opts ...tracer.StartSpanOption,
 ) (ddtrace.Span, context.Context) {
@@ -30,6 +32,7 @@ func startSpanFromContext(
 		tracer.ResourceName(method),
 		tracer.Tag(tagMethodName, method),
 		tracer.SpanType(ext.AppTypeRPC),
+		tracer.Tag(ext.Component, componentName),
 	)
 	md, _ := metadata.FromIncomingContext(ctx) // nil is ok
 	if sctx, err := tracer.Extract(grpcutil.MDCarrier(md)); err == nil {
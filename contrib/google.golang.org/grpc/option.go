@@ -29,6 +29,8 @@ type config struct {
 	withMetadataTags    bool
 	ignoredMetadata     map[string]struct{}
 	withRequestTags     bool
+	appsecBlocking      bool
+	appsecBlockingCode  codes.Code
 }
 
 func (cfg *config) serverServiceName() string {
@@ -171,3 +173,15 @@ func WithRequestTags() Option {
 		cfg.withRequestTags = true
 	}
 }
+
+// WithAppSecBlocking enables the blocking of RPCs in which AppSec detected an attack, by
+// aborting them with the given status code. Unary RPCs are blocked before calling the handler.
+// Streaming RPCs are blocked when the stream opens if the attack was found in the metadata,
+// or otherwise by failing the RecvMsg call of the message containing the attack. This option
+// has no effect when AppSec is disabled.
+func WithAppSecBlocking(code codes.Code) Option {
+	return func(cfg *config) {
+		cfg.appsecBlocking = true
+		cfg.appsecBlockingCode = code
+	}
+}
@@ -12,6 +12,7 @@ const (
 	tagCode           = "grpc.code"
 	tagMetadataPrefix = "grpc.metadata."
 	tagRequest        = "grpc.request"
+	tagAppSecBlocked  = "appsec.blocked"
 )
 
 const (
@@ -8,16 +8,18 @@ package mux // import "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorilla/mux"
 
 import (
 	"net/http"
-	"strings"
 
 	httptrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/net/http"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
 
 	"github.com/gorilla/mux"
 )
 
+const componentName = "gorilla/mux"
+
 // Router registers routes to be matched and dispatches a handler.
 type Router struct {
 	*mux.Router
@@ -98,20 +100,21 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 		route, _ = match.Route.GetPathTemplate()
 	}
+	spanopts = append(spanopts, tracer.Tag(ext.Component, componentName))
 	spanopts = append(spanopts, r.config.spanOpts...)
-	if r.config.headerTags {
-		spanopts = append(spanopts, headerTagsFromRequest(req))
-	}
-	resource := r.config.resourceNamer(r, req)
-	httptrace.TraceAndServe(r.Router, w, req, &httptrace.ServeConfig{
+	cfg := &httptrace.ServeConfig{
 		Service:     r.config.serviceName,
-		Resource:    resource,
+		Resource:    r.config.resourceNamer(r, req),
 		FinishOpts:  r.config.finishOpts,
 		SpanOpts:    spanopts,
 		QueryParams: r.config.queryParams,
 		RouteParams: match.Vars,
 		Route:       route,
-	})
+	}
+	if r.config.headerTags {
+		cfg.HeaderTags = requestHeaderTags(req)
+	}
+	httptrace.TraceAndServe(r.Router, w, req, cfg)
 }
 
 // WrapRouter returns the given router wrapped with the tracing of the HTTP
@@ -137,13 +140,3 @@ func defaultResourceNamer(router *Router, req *http.Request) string {
 	}
 	return req.Method + " unknown"
 }
-
-func headerTagsFromRequest(req *http.Request) ddtrace.StartSpanOption {
-	return func(cfg *ddtrace.StartSpanConfig) {
-		for k := range req.Header {
-			if !strings.HasPrefix(strings.ToLower(k), "x-datadog-") {
-				cfg.Tags["http.request.headers."+k] = strings.Join(req.Header.Values(k), ",")
-			}
-		}
-	}
-}
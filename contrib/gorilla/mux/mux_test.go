@@ -116,8 +116,9 @@ func TestWithHeaderTags(t *testing.T) {
 	mux.ServeHTTP(httptest.NewRecorder(), r)
 
 	spans := mt.FinishedSpans()
-	assert.Equal("header-value", spans[0].Tags()["http.request.headers.Header"])
-	assert.NotContains(spans[0].Tags(), "http.headers.X-Datadog-Header")
+	assert.Equal("header-value", spans[0].Tags()["http.request.headers.header"])
+	assert.NotContains(spans[0].Tags(), "http.request.headers.x-datadog-header")
+	assert.NotContains(spans[0].Tags(), "http.request.headers.Header")
 }
 
 func TestWithQueryParams(t *testing.T) {
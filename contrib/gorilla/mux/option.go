@@ -8,7 +8,9 @@ package mux
 import (
 	"math"
 	"net/http"
+	"strings"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -120,7 +122,7 @@ func WithResourceNamer(namer func(router *Router, req *http.Request) string) Rou
 }
 
 // WithHeaderTags specifies that the integration should attach HTTP request headers as
-// tags to spans.
+// tags to spans, named as the other HTTP integrations do, e.g. http.request.headers.x-foo.
 // Warning: using this feature can risk exposing sensitive data such as authorisation tokens
 // to Datadog.
 func WithHeaderTags() RouterOption {
@@ -129,6 +131,37 @@ func WithHeaderTags() RouterOption {
 	}
 }
 
+// requestHeaderTags returns the header tags to use for req when WithHeaderTags is set: the
+// default ones, along with all the other request headers except the Datadog ones.
+func requestHeaderTags(req *http.Request) []string {
+	defaults := httptrace.HeaderTags()
+	headers := make([]string, 0, len(defaults)+len(req.Header))
+	headers = append(headers, defaults...)
+	configured := make(map[string]struct{}, len(defaults))
+	for _, h := range defaults {
+		if i := strings.IndexByte(h, ':'); i >= 0 {
+			h = h[:i]
+		}
+		configured[h] = struct{}{}
+	}
+	for _, h := range httptrace.NormalizeHeaderTags(headerNames(req.Header)) {
+		if _, ok := configured[h]; ok || strings.HasPrefix(h, "X-Datadog-") {
+			continue
+		}
+		headers = append(headers, h)
+	}
+	return headers
+}
+
+// headerNames returns the names of the headers in h.
+func headerNames(h http.Header) []string {
+	names := make([]string, 0, len(h))
+	for k := range h {
+		names = append(names, k)
+	}
+	return names
+}
+
 // WithQueryParams specifies that the integration should attach request query parameters as APM tags.
 // Warning: using this feature can risk exposing sensitive data such as authorisation tokens
 // to Datadog.
@@ -8,6 +8,7 @@ package httptrace
 import (
 	"os"
 	"regexp"
+	"strings"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
@@ -24,6 +25,9 @@ const (
 	envClientIPHeader = "DD_TRACE_CLIENT_IP_HEADER"
 	// envClientIPHeader is the name of the env var used to disable client IP tag collection.
 	envClientIPHeaderDisabled = "DD_TRACE_CLIENT_IP_HEADER_DISABLED"
+	// envHeaderTags is the name of the env var used to specify a comma-separated list of request and response
+	// headers to be added as span tags, in the form header[:tag_name].
+	envHeaderTags = "DD_TRACE_HEADER_TAGS"
 )
 
 // defaultQueryStringRegexp is the regexp used for query string obfuscation if `envQueryStringRegexp` is empty.
@@ -35,6 +39,7 @@ type config struct {
 	clientIPHeader    string         // specifies the header to use for IP extraction if client IP tag collection is enabled.
 	clientIP          bool           // reports whether the IP should be extracted from the request headers and added to span tags.
 	queryString       bool           // reports whether the query string should be included in the URL span tag.
+	headerTags        []string       // specifies the request and response headers to add as span tags by default.
 }
 
 func newConfig() config {
@@ -44,6 +49,9 @@ func newConfig() config {
 		queryString:       !internal.BoolEnv(envQueryStringDisabled, false),
 		queryStringRegexp: defaultQueryStringRegexp,
 	}
+	if s := os.Getenv(envHeaderTags); s != "" {
+		c.headerTags = NormalizeHeaderTags(strings.Split(s, ","))
+	}
 	if s, ok := os.LookupEnv(envQueryStringRegexp); !ok {
 		return c
 	} else if s == "" {
@@ -60,6 +60,26 @@ func TestConfig(t *testing.T) {
 				clientIP:    true,
 			},
 		},
+		{
+			name: "header-tags",
+			env:  map[string]string{envHeaderTags: " x-request-id, ,user-agent"},
+			cfg: config{
+				queryString:       true,
+				clientIP:          true,
+				queryStringRegexp: defaultQueryStringRegexp,
+				headerTags:        []string{"X-Request-Id", "User-Agent"},
+			},
+		},
+		{
+			name: "header-tags-mapping",
+			env:  map[string]string{envHeaderTags: "x-request-id:request_id, user-agent : http.useragent,x-empty:,:tag"},
+			cfg: config{
+				queryString:       true,
+				clientIP:          true,
+				queryStringRegexp: defaultQueryStringRegexp,
+				headerTags:        []string{"X-Request-Id:request_id", "User-Agent:http.useragent", "X-Empty"},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			defer cleanEnv()()
@@ -71,6 +91,7 @@ func TestConfig(t *testing.T) {
 			require.Equal(t, tc.cfg.queryString, c.queryString)
 			require.Equal(t, tc.cfg.clientIPHeader, c.clientIPHeader)
 			require.Equal(t, tc.cfg.clientIP, c.clientIP)
+			require.Equal(t, tc.cfg.headerTags, c.headerTags)
 		})
 	}
 }
@@ -81,6 +102,7 @@ func cleanEnv() func() {
 		envQueryStringRegexp:      os.Getenv(envQueryStringRegexp),
 		envClientIPHeaderDisabled: os.Getenv(envClientIPHeaderDisabled),
 		envClientIPHeader:         os.Getenv(envClientIPHeader),
+		envHeaderTags:             os.Getenv(envHeaderTags),
 	}
 	for k := range env {
 		os.Unsetenv(k)
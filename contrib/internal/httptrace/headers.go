@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2022 Datadog, Inc.
+
+package httptrace
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+)
+
+// HeaderTags returns the request and response headers to add as span tags by default, as
+// configured with the DD_TRACE_HEADER_TAGS environment variable.
+func HeaderTags() []string {
+	return cfg.headerTags
+}
+
+// NormalizeHeaderTags normalizes the given header tags, each of the form header[:tag_name].
+// Header names are put in their canonical form and empty entries are dropped. The result is
+// never nil, so that it can override the default HeaderTags.
+func NormalizeHeaderTags(headers []string) []string {
+	normalized := make([]string, 0, len(headers))
+	for _, h := range headers {
+		var tag string
+		if i := strings.IndexByte(h, ':'); i >= 0 {
+			h, tag = h[:i], strings.TrimSpace(h[i+1:])
+		}
+		if h = strings.TrimSpace(h); h == "" {
+			continue
+		}
+		h = textproto.CanonicalMIMEHeaderKey(h)
+		if tag != "" {
+			h += ":" + tag
+		}
+		normalized = append(normalized, h)
+	}
+	return normalized
+}
+
+// HeaderTagsFromRequest returns a span start option setting the values of the given request
+// headers as tags. The headers are expected to be normalized with NormalizeHeaderTags; those
+// without a tag name are set as http.request.headers.<header> tags.
+func HeaderTagsFromRequest(r *http.Request, headers []string) ddtrace.StartSpanOption {
+	return func(cfg *ddtrace.StartSpanConfig) {
+		for _, h := range headers {
+			h, tag := splitHeaderTag(ext.HTTPRequestHeaders, h)
+			if v := r.Header.Values(h); len(v) > 0 {
+				if cfg.Tags == nil {
+					cfg.Tags = map[string]interface{}{}
+				}
+				cfg.Tags[tag] = strings.Join(v, ",")
+			}
+		}
+	}
+}
+
+// SetResponseHeaderTags sets the values of the given response headers as tags on span. The
+// headers are expected to be normalized with NormalizeHeaderTags; those without a tag name
+// are set as http.response.headers.<header> tags.
+func SetResponseHeaderTags(span ddtrace.Span, header http.Header, headers []string) {
+	for _, h := range headers {
+		h, tag := splitHeaderTag(ext.HTTPResponseHeaders, h)
+		if v := header.Values(h); len(v) > 0 {
+			span.SetTag(tag, strings.Join(v, ","))
+		}
+	}
+}
+
+// splitHeaderTag returns the header name and the tag name of the normalized header tag h.
+// The tag name defaults to the one returned by headerTag for prefix.
+func splitHeaderTag(prefix, h string) (header, tag string) {
+	if i := strings.IndexByte(h, ':'); i >= 0 {
+		return h[:i], h[i+1:]
+	}
+	return h, headerTag(prefix, h)
+}
+
+// headerTag returns the name of the tag holding the value of header under prefix. The header
+// name is lowercased and any character other than letters, digits, '-' and '_' is replaced
+// with '_', e.g. "X-Request-Id" becomes "<prefix>.x-request-id".
+func headerTag(prefix, header string) string {
+	var b strings.Builder
+	b.Grow(len(prefix) + 1 + len(header))
+	b.WriteString(prefix)
+	b.WriteByte('.')
+	for _, c := range strings.ToLower(header) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_':
+			b.WriteRune(c)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2022 Datadog, Inc.
+
+package httptrace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+func TestHeaderTags(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	headers := NormalizeHeaderTags([]string{"x-request-id", " X-Tenant-Id ", "", "x.custom", "x-missing"})
+	assert.Equal(t, []string{"X-Request-Id", "X-Tenant-Id", "X.custom", "X-Missing"}, headers)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "123")
+	r.Header.Add("X-Tenant-Id", "a")
+	r.Header.Add("X-Tenant-Id", "b")
+	r.Header.Set("X.custom", "custom")
+	r.Header.Set("X-Other", "other")
+	s, _ := StartRequestSpan(r, HeaderTagsFromRequest(r, headers))
+
+	w := httptest.NewRecorder()
+	w.Header().Set("X-Request-Id", "456")
+	SetResponseHeaderTags(s, w.Header(), headers)
+	s.Finish()
+
+	spans := mt.FinishedSpans()
+	require.Len(t, spans, 1)
+	tags := spans[0].Tags()
+	assert.Equal(t, "123", tags["http.request.headers.x-request-id"])
+	assert.Equal(t, "a,b", tags["http.request.headers.x-tenant-id"])
+	assert.Equal(t, "custom", tags["http.request.headers.x_custom"])
+	assert.Equal(t, "456", tags["http.response.headers.x-request-id"])
+	assert.NotContains(t, tags, "http.request.headers.x-other")
+	assert.NotContains(t, tags, "http.request.headers.x-missing")
+	assert.NotContains(t, tags, "http.response.headers.x-tenant-id")
+}
+
+func TestHeaderTagsMapping(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	headers := NormalizeHeaderTags([]string{"x-request-id:request_id", "x-tenant-id"})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "123")
+	r.Header.Set("X-Tenant-Id", "a")
+	// the header tags option may come first, before any option initializing the tags
+	s := tracer.StartSpan("http.request", HeaderTagsFromRequest(r, headers))
+
+	w := httptest.NewRecorder()
+	w.Header().Set("X-Tenant-Id", "b")
+	SetResponseHeaderTags(s, w.Header(), headers)
+	s.Finish()
+
+	spans := mt.FinishedSpans()
+	require.Len(t, spans, 1)
+	tags := spans[0].Tags()
+	assert.Equal(t, "123", tags["request_id"])
+	assert.Equal(t, "a", tags["http.request.headers.x-tenant-id"])
+	assert.Equal(t, "b", tags["http.response.headers.x-tenant-id"])
+	assert.NotContains(t, tags, "http.request.headers.x-request-id")
+}
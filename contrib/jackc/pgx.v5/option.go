@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+//go:build go1.18
+// +build go1.18
+
+package pgx
+
+import (
+	"math"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
+)
+
+type config struct {
+	serviceName    string
+	analyticsRate  float64
+	traceBatch     bool
+	traceCopyFrom  bool
+	tracePrepare   bool
+	traceConnect   bool
+	childSpansOnly bool
+}
+
+// Option represents an option that can be used to create or wrap a connection.
+type Option func(*config)
+
+func defaults(cfg *config) {
+	service := "postgres.db"
+	if svc := globalconfig.ServiceName(); svc != "" {
+		service = svc
+	}
+	cfg.serviceName = service
+	// cfg.analyticsRate = globalconfig.AnalyticsRate()
+	if internal.BoolEnv("DD_TRACE_PGX_ANALYTICS_ENABLED", false) {
+		cfg.analyticsRate = 1.0
+	} else {
+		cfg.analyticsRate = math.NaN()
+	}
+	cfg.traceBatch = true
+	cfg.traceCopyFrom = true
+	cfg.tracePrepare = true
+	cfg.traceConnect = true
+}
+
+// WithServiceName sets the given service name for the connection.
+func WithServiceName(name string) Option {
+	return func(cfg *config) {
+		cfg.serviceName = name
+	}
+}
+
+// WithAnalytics enables Trace Analytics for all started spans.
+func WithAnalytics(on bool) Option {
+	return func(cfg *config) {
+		if on {
+			cfg.analyticsRate = 1.0
+		} else {
+			cfg.analyticsRate = math.NaN()
+		}
+	}
+}
+
+// WithAnalyticsRate sets the sampling rate for Trace Analytics events
+// correlated to started spans.
+func WithAnalyticsRate(rate float64) Option {
+	return func(cfg *config) {
+		if rate >= 0.0 && rate <= 1.0 {
+			cfg.analyticsRate = rate
+		} else {
+			cfg.analyticsRate = math.NaN()
+		}
+	}
+}
+
+// WithTraceBatch enables or disables tracing of batch operations (SendBatch).
+// It is enabled by default.
+func WithTraceBatch(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.traceBatch = enabled
+	}
+}
+
+// WithTraceCopyFrom enables or disables tracing of CopyFrom operations.
+// It is enabled by default.
+func WithTraceCopyFrom(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.traceCopyFrom = enabled
+	}
+}
+
+// WithTracePrepare enables or disables tracing of prepared statements.
+// It is enabled by default.
+func WithTracePrepare(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.tracePrepare = enabled
+	}
+}
+
+// WithTraceConnect enables or disables tracing of connection establishment.
+// It is enabled by default.
+func WithTraceConnect(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.traceConnect = enabled
+	}
+}
+
+// WithChildSpansOnly causes spans to be created only when
+// there is an existing parent span in the Context.
+func WithChildSpansOnly() Option {
+	return func(cfg *config) {
+		cfg.childSpansOnly = true
+	}
+}
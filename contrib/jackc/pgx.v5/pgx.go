@@ -0,0 +1,38 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+//go:build go1.18
+// +build go1.18
+
+// Package pgx provides functions to trace the jackc/pgx package (https://github.com/jackc/pgx).
+// This package supports pgx v5, which requires Go 1.18 or later.
+package pgx // import "gopkg.in/DataDog/dd-trace-go.v1/contrib/jackc/pgx.v5"
+
+import (
+	"context"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Connect establishes a connection with a PostgreSQL server using the given
+// connection string. The returned connection is traced.
+func Connect(ctx context.Context, connString string, opts ...Option) (*pgx.Conn, error) {
+	connConfig, err := pgx.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	return ConnectConfig(ctx, connConfig, opts...)
+}
+
+// ConnectConfig establishes a connection with a PostgreSQL server using the
+// given configuration. The returned connection is traced. Any tracer already
+// set on connConfig is replaced.
+func ConnectConfig(ctx context.Context, connConfig *pgx.ConnConfig, opts ...Option) (*pgx.Conn, error) {
+	log.Debug("contrib/jackc/pgx.v5: Connecting")
+	connConfig.Tracer = newPgxTracer(opts...)
+	return pgx.ConnectConfig(ctx, connConfig)
+}
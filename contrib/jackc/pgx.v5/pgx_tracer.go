@@ -0,0 +1,238 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+//go:build go1.18
+// +build go1.18
+
+package pgx
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const componentName = "jackc/pgx.v5"
+
+type operationType string
+
+const (
+	operationTypeConnect    operationType = "Connect"
+	operationTypeQuery                    = "Query"
+	operationTypePrepare                  = "Prepare"
+	operationTypeBatch                    = "Batch"
+	operationTypeBatchQuery               = "BatchQuery"
+	operationTypeCopyFrom                 = "CopyFrom"
+)
+
+const (
+	tagQueryType       = "sql.query_type"
+	tagRowsAffected    = "db.result.rows_affected"
+	tagBatchNumQueries = "db.batch.num_queries"
+	tagCopyFromTables  = "db.copy_from.tables"
+	tagCopyFromColumns = "db.copy_from.columns"
+	tagPreparedName    = "db.prepared_statement.name"
+)
+
+var (
+	_ pgx.QueryTracer    = (*pgxTracer)(nil)
+	_ pgx.BatchTracer    = (*pgxTracer)(nil)
+	_ pgx.ConnectTracer  = (*pgxTracer)(nil)
+	_ pgx.PrepareTracer  = (*pgxTracer)(nil)
+	_ pgx.CopyFromTracer = (*pgxTracer)(nil)
+)
+
+// pgxTracer implements the pgx tracer interfaces, creating a span for
+// each traced operation.
+type pgxTracer struct {
+	cfg *config
+}
+
+func newPgxTracer(opts ...Option) *pgxTracer {
+	cfg := new(config)
+	defaults(cfg)
+	for _, fn := range opts {
+		fn(cfg)
+	}
+	return &pgxTracer{cfg: cfg}
+}
+
+type contextKey int
+
+const (
+	spanKey       contextKey = iota // ddtrace.Span
+	batchStateKey                   // *batchState
+)
+
+// batchState keeps track of the progress of a batch so that each query in it
+// can be given its own span.
+type batchState struct {
+	lastQueryEnd time.Time
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return t.startSpan(ctx, configOf(conn), "pgx.query", operationTypeQuery, data.SQL)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *pgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	finishSpan(ctx, data.CommandTag, data.Err)
+}
+
+// TraceBatchStart implements pgx.BatchTracer.
+func (t *pgxTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	if !t.cfg.traceBatch {
+		return ctx
+	}
+	ctx = t.startSpan(ctx, configOf(conn), "pgx.batch", operationTypeBatch, "",
+		tracer.Tag(tagBatchNumQueries, data.Batch.Len()),
+	)
+	if _, ok := ctx.Value(spanKey).(ddtrace.Span); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, batchStateKey, &batchState{lastQueryEnd: time.Now()})
+}
+
+// TraceBatchQuery implements pgx.BatchTracer. pgx calls it once the result of
+// each query in the batch has been read, so the span covers the time elapsed
+// since the previous query of the batch completed.
+func (t *pgxTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	state, ok := ctx.Value(batchStateKey).(*batchState)
+	if !ok {
+		return
+	}
+	start := state.lastQueryEnd
+	state.lastQueryEnd = time.Now()
+	ctx = t.startSpan(ctx, configOf(conn), "pgx.batch.query", operationTypeBatchQuery, data.SQL, tracer.StartTime(start))
+	finishSpan(ctx, data.CommandTag, data.Err, tracer.FinishTime(state.lastQueryEnd))
+}
+
+// TraceBatchEnd implements pgx.BatchTracer.
+func (t *pgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	if _, ok := ctx.Value(batchStateKey).(*batchState); !ok {
+		return
+	}
+	finishSpan(ctx, pgconn.CommandTag{}, data.Err)
+}
+
+// TraceCopyFromStart implements pgx.CopyFromTracer.
+func (t *pgxTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	if !t.cfg.traceCopyFrom {
+		return ctx
+	}
+	return t.startSpan(ctx, configOf(conn), "pgx.copy_from", operationTypeCopyFrom, "",
+		tracer.Tag(tagCopyFromTables, data.TableName.Sanitize()),
+		tracer.Tag(tagCopyFromColumns, strings.Join(data.ColumnNames, ",")),
+	)
+}
+
+// TraceCopyFromEnd implements pgx.CopyFromTracer.
+func (t *pgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	if !t.cfg.traceCopyFrom {
+		return
+	}
+	finishSpan(ctx, data.CommandTag, data.Err)
+}
+
+// TracePrepareStart implements pgx.PrepareTracer.
+func (t *pgxTracer) TracePrepareStart(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
+	if !t.cfg.tracePrepare {
+		return ctx
+	}
+	return t.startSpan(ctx, configOf(conn), "pgx.prepare", operationTypePrepare, data.SQL,
+		tracer.Tag(tagPreparedName, data.Name),
+	)
+}
+
+// TracePrepareEnd implements pgx.PrepareTracer.
+func (t *pgxTracer) TracePrepareEnd(ctx context.Context, _ *pgx.Conn, data pgx.TracePrepareEndData) {
+	if !t.cfg.tracePrepare {
+		return
+	}
+	finishSpan(ctx, pgconn.CommandTag{}, data.Err)
+}
+
+// TraceConnectStart implements pgx.ConnectTracer.
+func (t *pgxTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	if !t.cfg.traceConnect {
+		return ctx
+	}
+	return t.startSpan(ctx, data.ConnConfig, "pgx.connect", operationTypeConnect, "")
+}
+
+// TraceConnectEnd implements pgx.ConnectTracer.
+func (t *pgxTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	if !t.cfg.traceConnect {
+		return
+	}
+	finishSpan(ctx, pgconn.CommandTag{}, data.Err)
+}
+
+// configOf returns the configuration of conn, or nil when there is no connection.
+func configOf(conn *pgx.Conn) *pgx.ConnConfig {
+	if conn == nil {
+		return nil
+	}
+	return conn.Config()
+}
+
+// startSpan starts a span with the given name for the given operation and returns
+// a context holding it. The query, when non-empty, is used as the resource name.
+func (t *pgxTracer) startSpan(ctx context.Context, connConfig *pgx.ConnConfig, name string, op operationType, query string, extraOpts ...ddtrace.StartSpanOption) context.Context {
+	if _, exists := tracer.SpanFromContext(ctx); t.cfg.childSpansOnly && !exists {
+		return ctx
+	}
+	resource := string(op)
+	if query != "" {
+		resource = query
+	}
+	opts := append([]ddtrace.StartSpanOption{
+		tracer.ServiceName(t.cfg.serviceName),
+		tracer.SpanType(ext.SpanTypeSQL),
+		tracer.ResourceName(resource),
+		tracer.Tag(ext.DBSystem, ext.DBSystemPostgreSQL),
+		tracer.Tag(tagQueryType, string(op)),
+		tracer.Tag(ext.Component, componentName),
+	}, extraOpts...)
+	if connConfig != nil {
+		opts = append(opts,
+			tracer.Tag(ext.DBName, connConfig.Database),
+			tracer.Tag(ext.DBUser, connConfig.User),
+			tracer.Tag(ext.TargetHost, connConfig.Host),
+			tracer.Tag(ext.TargetPort, strconv.Itoa(int(connConfig.Port))),
+		)
+	}
+	if !math.IsNaN(t.cfg.analyticsRate) {
+		opts = append(opts, tracer.Tag(ext.EventSampleRate, t.cfg.analyticsRate))
+	}
+	span, ctx := tracer.StartSpanFromContext(ctx, name, opts...)
+	// The span is also stored under a key owned by this package, so that the
+	// matching end hook never finishes a span it did not start (e.g. a parent
+	// span when WithChildSpansOnly is used).
+	return context.WithValue(ctx, spanKey, span)
+}
+
+// finishSpan finishes the span started by startSpan in ctx, if any. The rows
+// affected by tag are recorded when the operation succeeded.
+func finishSpan(ctx context.Context, tag pgconn.CommandTag, err error, opts ...ddtrace.FinishOption) {
+	span, ok := ctx.Value(spanKey).(ddtrace.Span)
+	if !ok {
+		return
+	}
+	if err == nil && tag.String() != "" {
+		span.SetTag(tagRowsAffected, tag.RowsAffected())
+	}
+	span.Finish(append(opts, tracer.WithError(err))...)
+}
@@ -0,0 +1,36 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+//go:build go1.18
+// +build go1.18
+
+package pgx
+
+import (
+	"context"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPool creates a new connection pool using the given connection string.
+// All connections created by the pool are traced.
+func NewPool(ctx context.Context, connString string, opts ...Option) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	return NewPoolWithConfig(ctx, config, opts...)
+}
+
+// NewPoolWithConfig creates a new connection pool using the given configuration.
+// All connections created by the pool are traced. Any tracer already set on
+// config.ConnConfig is replaced.
+func NewPoolWithConfig(ctx context.Context, config *pgxpool.Config, opts ...Option) (*pgxpool.Pool, error) {
+	log.Debug("contrib/jackc/pgx.v5: Creating pool")
+	config.ConnConfig.Tracer = newPgxTracer(opts...)
+	return pgxpool.NewWithConfig(ctx, config)
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
+const componentName = "julienschmidt/httprouter"
+
 // Router is a traced version of httprouter.Router.
 type Router struct {
 	*httprouter.Router
@@ -35,6 +37,7 @@ func New(opts ...RouterOption) *Router {
 	if !math.IsNaN(cfg.analyticsRate) {
 		cfg.spanOpts = append(cfg.spanOpts, tracer.Tag(ext.EventSampleRate, cfg.analyticsRate))
 	}
+	cfg.spanOpts = append(cfg.spanOpts, tracer.Tag(ext.Component, componentName))
 	log.Debug("contrib/julienschmidt/httprouter: Configuring Router: %#v", cfg)
 	return &Router{httprouter.New(), cfg}
 }
@@ -49,8 +52,9 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 	resource := req.Method + " " + route
 	httptrace.TraceAndServe(r.Router, w, req, &httptrace.ServeConfig{
-		Service:  r.config.serviceName,
-		Resource: resource,
-		SpanOpts: r.config.spanOpts,
+		Service:    r.config.serviceName,
+		Resource:   resource,
+		SpanOpts:   r.config.spanOpts,
+		HeaderTags: r.config.headerTags,
 	})
 }
@@ -44,6 +44,7 @@ func TestHttpTracer200(t *testing.T) {
 	assert.Equal("http://example.com"+url, s.Tag(ext.HTTPURL))
 	assert.Equal("testvalue", s.Tag("testkey"))
 	assert.Equal(nil, s.Tag(ext.Error))
+	assert.Equal("julienschmidt/httprouter", s.Tag(ext.Component))
 }
 
 func TestHttpTracer500(t *testing.T) {
@@ -150,3 +151,24 @@ func handler200(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 func handler500(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	http.Error(w, "500!", http.StatusInternalServerError)
 }
+
+func TestHeaderTags(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	// an empty list must be passed on as such, as a nil ServeConfig.HeaderTags would fall back
+	// to the headers configured with DD_TRACE_HEADER_TAGS
+	assert.NotNil(t, New(WithHeaderTags([]string{})).config.headerTags)
+
+	router := New(WithHeaderTags([]string{"x-request-id:request_id"}))
+	router.GET("/", func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		w.Write([]byte("OK"))
+	})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-Id", "123")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := mt.FinishedSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "123", spans[0].Tag("request_id"))
+}
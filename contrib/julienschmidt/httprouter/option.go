@@ -8,6 +8,7 @@ package httprouter
 import (
 	"math"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
@@ -17,6 +18,7 @@ type routerConfig struct {
 	serviceName   string
 	spanOpts      []ddtrace.StartSpanOption
 	analyticsRate float64
+	headerTags    []string
 }
 
 // RouterOption represents an option that can be passed to New.
@@ -32,6 +34,7 @@ func defaults(cfg *routerConfig) {
 	if svc := globalconfig.ServiceName(); svc != "" {
 		cfg.serviceName = svc
 	}
+	cfg.headerTags = httptrace.HeaderTags()
 }
 
 // WithServiceName sets the given service name for the returned router.
@@ -70,3 +73,11 @@ func WithAnalyticsRate(rate float64) RouterOption {
 		}
 	}
 }
+
+// WithHeaderTags specifies the request and response headers to add as span tags, in the
+// header[:tag_name] form of the DD_TRACE_HEADER_TAGS environment variable, which it overrides.
+func WithHeaderTags(headers []string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
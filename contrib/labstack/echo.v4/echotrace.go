@@ -19,6 +19,8 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const componentName = "labstack/echo.v4"
+
 // Middleware returns echo middleware which will trace incoming requests.
 func Middleware(opts ...Option) echo.MiddlewareFunc {
 	appsecEnabled := appsec.Enabled()
@@ -30,6 +32,7 @@ func Middleware(opts ...Option) echo.MiddlewareFunc {
 	log.Debug("contrib/labstack/echo.v4: Configuring Middleware: %#v", cfg)
 	spanOpts := []ddtrace.StartSpanOption{
 		tracer.ServiceName(cfg.serviceName),
+		tracer.Tag(ext.Component, componentName),
 	}
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -56,8 +59,12 @@ func Middleware(opts ...Option) echo.MiddlewareFunc {
 				finishOpts = []tracer.FinishOption{tracer.NoDebugStack()}
 			}
 
+			if len(cfg.headerTags) > 0 {
+				opts = append(opts, httptrace.HeaderTagsFromRequest(request, cfg.headerTags))
+			}
 			span, ctx := httptrace.StartRequestSpan(request, opts...)
 			defer func() {
+				httptrace.SetResponseHeaderTags(span, c.Response().Header(), cfg.headerTags)
 				httptrace.FinishRequestSpan(span, c.Response().Status, finishOpts...)
 			}()
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
 )
 
@@ -18,6 +19,7 @@ type config struct {
 	analyticsRate     float64
 	noDebugStack      bool
 	ignoreRequestFunc IgnoreRequestFunc
+	headerTags        []string
 }
 
 // Option represents an option that can be passed to Middleware.
@@ -32,6 +34,7 @@ func defaults(cfg *config) {
 		cfg.serviceName = svc
 	}
 	cfg.analyticsRate = math.NaN()
+	cfg.headerTags = httptrace.HeaderTags()
 }
 
 // WithServiceName sets the given service name for the system.
@@ -80,3 +83,11 @@ func WithIgnoreRequest(ignoreRequestFunc IgnoreRequestFunc) Option {
 		cfg.ignoreRequestFunc = ignoreRequestFunc
 	}
 }
+
+// WithHeaderTags specifies the headers, each as header[:tag_name], whose values should be
+// added to the spans. It replaces the ones configured with DD_TRACE_HEADER_TAGS.
+func WithHeaderTags(headers []string) Option {
+	return func(cfg *config) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/labstack/echo"
 )
 
+const componentName = "labstack/echo"
+
 // Middleware returns echo middleware which will trace incoming requests.
 func Middleware(opts ...Option) echo.MiddlewareFunc {
 	cfg := new(config)
@@ -28,6 +30,7 @@ func Middleware(opts ...Option) echo.MiddlewareFunc {
 	log.Debug("contrib/labstack/echo: Configuring Middleware: %#v", cfg)
 	spanOpts := []ddtrace.StartSpanOption{
 		tracer.ServiceName(cfg.serviceName),
+		tracer.Tag(ext.Component, componentName),
 	}
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -44,8 +47,12 @@ func Middleware(opts ...Option) echo.MiddlewareFunc {
 				finishOpts = []tracer.FinishOption{tracer.NoDebugStack()}
 			}
 
+			if len(cfg.headerTags) > 0 {
+				opts = append(opts, httptrace.HeaderTagsFromRequest(request, cfg.headerTags))
+			}
 			span, ctx := httptrace.StartRequestSpan(request, opts...)
 			defer func() {
+				httptrace.SetResponseHeaderTags(span, c.Response().Header(), cfg.headerTags)
 				httptrace.FinishRequestSpan(span, c.Response().Status, finishOpts...)
 			}()
 
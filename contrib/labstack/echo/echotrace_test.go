@@ -266,3 +266,29 @@ func TestNoDebugStack(t *testing.T) {
 	assert.Equal(wantErr.Error(), span.Tag(ext.Error).(error).Error())
 	assert.Equal("<debug stack disabled>", span.Tag(ext.ErrorStack))
 }
+
+func TestHeaderTags(t *testing.T) {
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	router := echo.New()
+	// the default headers, normally configured with DD_TRACE_HEADER_TAGS, are replaced
+	withEnvHeaderTags := func(cfg *config) { cfg.headerTags = []string{"X-Other"} }
+	router.Use(Middleware(withEnvHeaderTags, WithHeaderTags([]string{"x-request-id:request_id", "x-response-id"})))
+	router.GET("/", func(c echo.Context) error {
+		c.Response().Header().Set("X-Response-Id", "456")
+		return c.String(200, "OK")
+	})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-Id", "123")
+	r.Header.Set("X-Other", "other")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := mt.FinishedSpans()
+	assert.Len(t, spans, 1)
+	tags := spans[0].Tags()
+	assert.Equal(t, "123", tags["request_id"])
+	assert.Equal(t, "456", tags["http.response.headers.x-response-id"])
+	assert.NotContains(t, tags, "http.request.headers.x-other")
+}
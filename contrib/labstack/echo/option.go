@@ -8,6 +8,7 @@ package echo
 import (
 	"math"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
 )
@@ -16,6 +17,7 @@ type config struct {
 	serviceName   string
 	analyticsRate float64
 	noDebugStack  bool
+	headerTags    []string
 }
 
 // Option represents an option that can be passed to Middleware.
@@ -31,6 +33,7 @@ func defaults(cfg *config) {
 	} else {
 		cfg.analyticsRate = math.NaN()
 	}
+	cfg.headerTags = httptrace.HeaderTags()
 }
 
 // WithServiceName sets the given service name for the system.
@@ -71,3 +74,11 @@ func NoDebugStack() Option {
 		cfg.noDebugStack = true
 	}
 }
+
+// WithHeaderTags specifies the headers, each as header[:tag_name], whose values should be
+// added to the spans. It replaces the ones configured with DD_TRACE_HEADER_TAGS.
+func WithHeaderTags(headers []string) Option {
+	return func(cfg *config) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
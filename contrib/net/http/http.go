@@ -46,10 +46,11 @@ func (mux *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_, route := mux.Handler(r)
 	resource := r.Method + " " + route
 	TraceAndServe(mux.ServeMux, w, r, &ServeConfig{
-		Service:  mux.cfg.serviceName,
-		Resource: resource,
-		SpanOpts: mux.cfg.spanOpts,
-		Route:    route,
+		Service:    mux.cfg.serviceName,
+		Resource:   resource,
+		SpanOpts:   mux.cfg.spanOpts,
+		Route:      route,
+		HeaderTags: mux.cfg.headerTags,
 	})
 }
 
@@ -76,6 +77,7 @@ func WrapHandler(h http.Handler, service, resource string, opts ...Option) http.
 			FinishOpts: cfg.finishOpts,
 			SpanOpts:   cfg.spanOpts,
 			Route:      req.URL.EscapedPath(),
+			HeaderTags: cfg.headerTags,
 		})
 	})
 }
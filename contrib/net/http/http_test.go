@@ -43,6 +43,7 @@ func TestHttpTracer200(t *testing.T) {
 	assert.Equal("http://example.com"+url, s.Tag(ext.HTTPURL))
 	assert.Equal(nil, s.Tag(ext.Error))
 	assert.Equal("bar", s.Tag("foo"))
+	assert.Equal("net/http", s.Tag(ext.Component))
 }
 
 func TestHttpTracer500(t *testing.T) {
@@ -250,6 +251,43 @@ func TestIgnoreRequestOption(t *testing.T) {
 	}
 }
 
+func TestHeaderTags(t *testing.T) {
+	// an empty list must be passed on as such, as a nil ServeConfig.HeaderTags falls back to
+	// the headers configured with DD_TRACE_HEADER_TAGS
+	assert.NotNil(t, NewServeMux(WithHeaderTags([]string{})).cfg.headerTags)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Response-Id", "456")
+		w.Write([]byte("OK\n"))
+	}
+	// withEnvHeaderTags stands for headers configured with DD_TRACE_HEADER_TAGS, which
+	// WithHeaderTags replaces
+	withEnvHeaderTags := func(cfg *config) { cfg.headerTags = []string{"X-Request-Id"} }
+	headers := []string{"x-response-id:response_id"}
+	for name, h := range map[string]http.Handler{
+		"servemux": func() http.Handler {
+			mux := NewServeMux(withEnvHeaderTags, WithHeaderTags(headers))
+			mux.HandleFunc("/", handler)
+			return mux
+		}(),
+		"wraphandler": WrapHandler(http.HandlerFunc(handler), "my-service", "my-resource", withEnvHeaderTags, WithHeaderTags(headers)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			mt := mocktracer.Start()
+			defer mt.Stop()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("X-Request-Id", "123")
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := mt.FinishedSpans()
+			assert.Len(t, spans, 1)
+			tags := spans[0].Tags()
+			assert.Equal(t, "456", tags["response_id"])
+			assert.NotContains(t, tags, "http.request.headers.x-request-id")
+		})
+	}
+}
+
 func router() http.Handler {
 	mux := NewServeMux(WithServiceName("my-service"), WithSpanOptions(tracer.Tag("foo", "bar")))
 	mux.HandleFunc("/200", handler200)
@@ -9,6 +9,7 @@ import (
 	"math"
 	"net/http"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -23,6 +24,7 @@ type config struct {
 	finishOpts    []ddtrace.FinishOption
 	ignoreRequest func(*http.Request) bool
 	resourceNamer func(*http.Request) string
+	headerTags    []string
 }
 
 // MuxOption has been deprecated in favor of Option.
@@ -47,6 +49,7 @@ func defaults(cfg *config) {
 	}
 	cfg.ignoreRequest = func(_ *http.Request) bool { return false }
 	cfg.resourceNamer = func(_ *http.Request) string { return "" }
+	cfg.headerTags = httptrace.HeaderTags()
 }
 
 // WithIgnoreRequest holds the function to use for determining if the
@@ -104,6 +107,16 @@ func WithResourceNamer(namer func(req *http.Request) string) Option {
 	}
 }
 
+// WithHeaderTags specifies the request and response headers whose values are added as span
+// tags, overriding the DD_TRACE_HEADER_TAGS environment variable. As in that variable, each
+// entry has the form header[:tag_name]. Without a tag name, the values are set as the
+// http.request.headers.<header> and http.response.headers.<header> tags.
+func WithHeaderTags(headers []string) Option {
+	return func(cfg *config) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
+
 // NoDebugStack prevents stack traces from being attached to spans finishing
 // with an error. This is useful in situations where errors are frequent and
 // performance is critical.
@@ -128,12 +141,14 @@ type roundTripperConfig struct {
 	serviceName   string
 	resourceNamer func(req *http.Request) string
 	spanOpts      []ddtrace.StartSpanOption
+	headerTags    []string
 }
 
 func newRoundTripperConfig() *roundTripperConfig {
 	return &roundTripperConfig{
 		analyticsRate: globalconfig.AnalyticsRate(),
 		resourceNamer: defaultResourceNamer,
+		headerTags:    httptrace.HeaderTags(),
 	}
 }
 
@@ -173,6 +188,13 @@ func RTWithSpanOptions(opts ...ddtrace.StartSpanOption) RoundTripperOption {
 	}
 }
 
+// RTWithHeaderTags is the equivalent of WithHeaderTags for the spans of outgoing requests.
+func RTWithHeaderTags(headers []string) RoundTripperOption {
+	return func(cfg *roundTripperConfig) {
+		cfg.headerTags = httptrace.NormalizeHeaderTags(headers)
+	}
+}
+
 func defaultResourceNamer(_ *http.Request) string {
 	return "http.request"
 }
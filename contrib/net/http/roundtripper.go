@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/contrib/internal/httptrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -27,6 +28,7 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (res *http.Response, err er
 	opts := []ddtrace.StartSpanOption{
 		tracer.SpanType(ext.SpanTypeHTTP),
 		tracer.ResourceName(resourceName),
+		tracer.Tag(ext.Component, componentName),
 		tracer.Tag(ext.HTTPMethod, req.Method),
 		tracer.Tag(ext.HTTPURL, req.URL.String()),
 	}
@@ -39,6 +41,9 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (res *http.Response, err er
 	if len(rt.cfg.spanOpts) > 0 {
 		opts = append(opts, rt.cfg.spanOpts...)
 	}
+	if len(rt.cfg.headerTags) > 0 {
+		opts = append(opts, httptrace.HeaderTagsFromRequest(req, rt.cfg.headerTags))
+	}
 	span, ctx := tracer.StartSpanFromContext(req.Context(), "http.request", opts...)
 	defer func() {
 		if rt.cfg.after != nil {
@@ -62,6 +67,7 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (res *http.Response, err er
 		span.SetTag(ext.Error, err)
 	} else {
 		span.SetTag(ext.HTTPCode, strconv.Itoa(res.StatusCode))
+		httptrace.SetResponseHeaderTags(span, res.Header, rt.cfg.headerTags)
 		// treat 5XX as errors
 		if res.StatusCode/100 == 5 {
 			span.SetTag("http.errors", res.Status)
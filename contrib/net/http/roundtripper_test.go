@@ -348,3 +348,24 @@ func TestSpanOptions(t *testing.T) {
 	assert.Len(t, spans, 1)
 	assert.Equal(t, tagValue, spans[0].Tag(tagKey))
 }
+
+func TestRoundTripperHeaderTags(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Response-Id", "456")
+		w.Write([]byte(""))
+	}))
+	defer s.Close()
+
+	mt := mocktracer.Start()
+	defer mt.Stop()
+	rt := WrapRoundTripper(http.DefaultTransport, RTWithHeaderTags([]string{"X-Request-Id", "x-response-id"}))
+	client := &http.Client{Transport: rt}
+	req, _ := http.NewRequest("GET", s.URL, nil)
+	req.Header.Set("X-Request-Id", "123")
+	client.Do(req)
+
+	spans := mt.FinishedSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "123", spans[0].Tag("http.request.headers.x-request-id"))
+	assert.Equal(t, "456", spans[0].Tag("http.response.headers.x-response-id"))
+}
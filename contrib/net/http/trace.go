@@ -18,6 +18,8 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/appsec/dyngo/instrumentation/httpsec"
 )
 
+const componentName = "net/http"
+
 // ServeConfig specifies the tracing configuration when using TraceAndServe.
 type ServeConfig struct {
 	// Service specifies the service name to use. If left blank, the global service name
@@ -37,6 +39,10 @@ type ServeConfig struct {
 	FinishOpts []ddtrace.FinishOption
 	// SpanOpts specifies any options to be applied to the request starting span.
 	SpanOpts []ddtrace.StartSpanOption
+	// HeaderTags specifies the request and response headers to be added as span tags, in the
+	// header[:tag_name] form. If nil, the headers specified with the DD_TRACE_HEADER_TAGS
+	// environment variable are used.
+	HeaderTags []string
 }
 
 // TraceAndServe serves the handler h using the given ResponseWriter and Request, applying tracing
@@ -45,11 +51,22 @@ func TraceAndServe(h http.Handler, w http.ResponseWriter, r *http.Request, cfg *
 	if cfg == nil {
 		cfg = new(ServeConfig)
 	}
-	opts := append(cfg.SpanOpts, tracer.ServiceName(cfg.Service), tracer.ResourceName(cfg.Resource))
+	// cfg.SpanOpts may be shared by several requests, so it is copied before appending the
+	// per-request options. It can override the component of integrations built on top of this one.
+	opts := append([]ddtrace.StartSpanOption{tracer.Tag(ext.Component, componentName)}, cfg.SpanOpts...)
+	opts = append(opts, tracer.ServiceName(cfg.Service), tracer.ResourceName(cfg.Resource))
 	opts = append(opts, tracer.Tag(ext.HTTPRoute, cfg.Route))
+	headerTags := cfg.HeaderTags
+	if headerTags == nil {
+		headerTags = httptrace.HeaderTags()
+	}
+	if len(headerTags) > 0 {
+		opts = append(opts, httptrace.HeaderTagsFromRequest(r, headerTags))
+	}
 	span, ctx := httptrace.StartRequestSpan(r, opts...)
 	rw, ddrw := wrapResponseWriter(w)
 	defer func() {
+		httptrace.SetResponseHeaderTags(span, rw.Header(), headerTags)
 		httptrace.FinishRequestSpan(span, ddrw.status, cfg.FinishOpts...)
 	}()
 
@@ -6,8 +6,12 @@
 package kafka
 
 import (
+	"context"
+
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/datastreams"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -52,3 +56,35 @@ func (c messageCarrier) Set(key, val string) {
 func ExtractSpanContext(msg kafka.Message) (ddtrace.SpanContext, error) {
 	return tracer.Extract(messageCarrier{&msg})
 }
+
+// injectPathway sets the encoded pathway p as a header of msg, replacing any
+// previous one.
+func injectPathway(msg *kafka.Message, p datastreams.Pathway) {
+	messageCarrier{msg}.Set(datastreams.PropagationKey, string(p.Encode()))
+}
+
+// extractPathway retrieves the pathway carried by the headers of msg, if any.
+func extractPathway(msg *kafka.Message) (datastreams.Pathway, bool) {
+	for _, h := range msg.Headers {
+		if h.Key != datastreams.PropagationKey {
+			continue
+		}
+		p, err := datastreams.Decode(h.Value)
+		if err != nil {
+			log.Debug("contrib/segmentio/kafka.go.v0: Failed to decode pathway: %v", err)
+			return p, false
+		}
+		return p, true
+	}
+	return datastreams.Pathway{}, false
+}
+
+// ContextWithPathway returns a copy of ctx carrying the Data Streams pathway of
+// msg, as set by a Reader using WithDataStreams. Messages written by a Writer
+// using WithDataStreams with the returned context continue that pathway.
+func ContextWithPathway(ctx context.Context, msg kafka.Message) context.Context {
+	if p, ok := extractPathway(&msg); ok {
+		return datastreams.ContextWithPathway(ctx, p)
+	}
+	return ctx
+}
@@ -0,0 +1,40 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016 Datadog, Inc.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/datastreams"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathwayPropagation(t *testing.T) {
+	msg := kafka.Message{Headers: []kafka.Header{{Key: "key", Value: []byte("value")}}}
+	_, ok := extractPathway(&msg)
+	assert.False(t, ok)
+
+	p := datastreams.NewPathway("direction:out", "topic:"+testTopic, "type:kafka")
+	injectPathway(&msg, p)
+	next := p.SetCheckpoint("direction:in", "topic:"+testTopic, "type:kafka")
+	injectPathway(&msg, next)
+	assert.Len(t, msg.Headers, 2, "the pathway header should be replaced")
+
+	got, ok := extractPathway(&msg)
+	assert.True(t, ok)
+	assert.Equal(t, next.GetHash(), got.GetHash())
+
+	ctxPathway, ok := datastreams.PathwayFromContext(ContextWithPathway(context.Background(), msg))
+	assert.True(t, ok)
+	assert.Equal(t, next.GetHash(), ctxPathway.GetHash())
+
+	msg.Headers[1].Value = []byte("invalid")
+	_, ok = extractPathway(&msg)
+	assert.False(t, ok)
+}
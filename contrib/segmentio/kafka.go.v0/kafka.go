@@ -8,15 +8,27 @@ package kafka
 import (
 	"context"
 	"math"
+	"strconv"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/datastreams"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
 )
 
+const componentName = "segmentio/kafka.go.v0"
+
+const (
+	tagTopic                 = "topic"
+	tagPathwayHash           = "pathway.hash"
+	tagPathwayMsSinceStart   = "pathway.ms_since_start"
+	tagPathwayMsSinceProduce = "pathway.ms_since_produce"
+)
+
 // NewReader calls kafka.NewReader and wraps the resulting Consumer.
 func NewReader(conf kafka.ReaderConfig, opts ...Option) *Reader {
 	return WrapReader(kafka.NewReader(conf), opts...)
@@ -49,8 +61,10 @@ func (r *Reader) startSpan(ctx context.Context, msg *kafka.Message) ddtrace.Span
 		tracer.ServiceName(r.cfg.consumerServiceName),
 		tracer.ResourceName("Consume Topic " + msg.Topic),
 		tracer.SpanType(ext.SpanTypeMessageConsumer),
+		tracer.Tag(ext.Component, componentName),
 		tracer.Tag("partition", msg.Partition),
 		tracer.Tag("offset", msg.Offset),
+		tracer.Tag(tagTopic, msg.Topic),
 		tracer.Measured(),
 	}
 	if !math.IsNaN(r.cfg.analyticsRate) {
@@ -66,9 +80,29 @@ func (r *Reader) startSpan(ctx context.Context, msg *kafka.Message) ddtrace.Span
 	if err := tracer.Inject(span.Context(), carrier); err != nil {
 		log.Debug("contrib/segmentio/kafka.go.v0: Failed to inject span context into carrier, %v", err)
 	}
+	if r.cfg.dataStreamsEnabled {
+		r.setConsumeCheckpoint(span, msg)
+	}
 	return span
 }
 
+// setConsumeCheckpoint sets a consume checkpoint on the pathway carried by msg,
+// tags span with the resulting pathway and reinjects it into msg so that it can
+// be continued by the messages produced while processing it.
+func (r *Reader) setConsumeCheckpoint(span ddtrace.Span, msg *kafka.Message) {
+	edgeTags := []string{"direction:in", "group:" + r.Config().GroupID, "topic:" + msg.Topic, "type:kafka"}
+	var p datastreams.Pathway
+	if parent, ok := extractPathway(msg); ok {
+		p = parent.SetCheckpoint(edgeTags...)
+		span.SetTag(tagPathwayMsSinceProduce, float64(p.EdgeStart().Sub(parent.EdgeStart()))/float64(time.Millisecond))
+	} else {
+		p = datastreams.NewPathway(edgeTags...)
+	}
+	span.SetTag(tagPathwayHash, strconv.FormatUint(p.GetHash(), 10))
+	span.SetTag(tagPathwayMsSinceStart, float64(p.EdgeStart().Sub(p.PathwayStart()))/float64(time.Millisecond))
+	injectPathway(msg, p)
+}
+
 // Close calls the underlying Reader.Close and if polling is enabled, finishes
 // any remaining span.
 func (r *Reader) Close() error {
@@ -125,18 +159,36 @@ type Writer struct {
 }
 
 func (w *Writer) startSpan(ctx context.Context, msg *kafka.Message) ddtrace.Span {
+	// the topic is either set on the writer or on each message
+	topic := w.Writer.Topic
+	if topic == "" {
+		topic = msg.Topic
+	}
 	opts := []tracer.StartSpanOption{
 		tracer.ServiceName(w.cfg.producerServiceName),
-		tracer.ResourceName("Produce Topic " + w.Writer.Topic),
+		tracer.ResourceName("Produce Topic " + topic),
 		tracer.SpanType(ext.SpanTypeMessageProducer),
+		tracer.Tag(ext.Component, componentName),
+		tracer.Tag(tagTopic, topic),
 	}
 	if !math.IsNaN(w.cfg.analyticsRate) {
 		opts = append(opts, tracer.Tag(ext.EventSampleRate, w.cfg.analyticsRate))
 	}
 	carrier := messageCarrier{msg}
 	span, _ := tracer.StartSpanFromContext(ctx, "kafka.produce", opts...)
-	err := tracer.Inject(span.Context(), carrier)
-	log.Debug("contrib/segmentio/kafka.go.v0: Failed to inject span context into carrier, %v", err)
+	if err := tracer.Inject(span.Context(), carrier); err != nil {
+		log.Debug("contrib/segmentio/kafka.go.v0: Failed to inject span context into carrier, %v", err)
+	}
+	if w.cfg.dataStreamsEnabled {
+		edgeTags := []string{"direction:out", "topic:" + topic, "type:kafka"}
+		var p datastreams.Pathway
+		if parent, ok := datastreams.PathwayFromContext(ctx); ok {
+			p = parent.SetCheckpoint(edgeTags...)
+		} else {
+			p = datastreams.NewPathway(edgeTags...)
+		}
+		injectPathway(msg, p)
+	}
 	return span
 }
 
@@ -81,6 +81,7 @@ func TestReadMessageFunctional(t *testing.T) {
 	assert.Equal(t, 0.1, s0.Tag(ext.EventSampleRate))
 	assert.Equal(t, "queue", s0.Tag(ext.SpanType))
 	assert.Equal(t, 0, s0.Tag("partition"))
+	assert.Equal(t, testTopic, s0.Tag("topic"))
 
 	s1 := spans[1] // consume
 	assert.Equal(t, "kafka.consume", s1.OperationName())
@@ -89,6 +90,7 @@ func TestReadMessageFunctional(t *testing.T) {
 	assert.Equal(t, nil, s1.Tag(ext.EventSampleRate))
 	assert.Equal(t, "queue", s1.Tag(ext.SpanType))
 	assert.Equal(t, 0, s1.Tag("partition"))
+	assert.Equal(t, testTopic, s1.Tag("topic"))
 }
 
 func TestFetchMessageFunctional(t *testing.T) {
@@ -142,6 +144,7 @@ func TestFetchMessageFunctional(t *testing.T) {
 	assert.Equal(t, 0.1, s0.Tag(ext.EventSampleRate))
 	assert.Equal(t, "queue", s0.Tag(ext.SpanType))
 	assert.Equal(t, 0, s0.Tag("partition"))
+	assert.Equal(t, testTopic, s0.Tag("topic"))
 
 	s1 := spans[1] // consume
 	assert.Equal(t, "kafka.consume", s1.OperationName())
@@ -150,4 +153,46 @@ func TestFetchMessageFunctional(t *testing.T) {
 	assert.Equal(t, nil, s1.Tag(ext.EventSampleRate))
 	assert.Equal(t, "queue", s1.Tag(ext.SpanType))
 	assert.Equal(t, 0, s1.Tag("partition"))
+	assert.Equal(t, testTopic, s1.Tag("topic"))
+}
+
+func TestDataStreamsFunctional(t *testing.T) {
+	skipIntegrationTest(t)
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	w := WrapWriter(&kafka.Writer{
+		Addr:         kafka.TCP("localhost:9092"),
+		RequiredAcks: kafka.RequireOne,
+	}, WithDataStreams())
+	err := w.WriteMessages(context.Background(), kafka.Message{
+		Topic: testTopic,
+		Key:   []byte("key1"),
+		Value: []byte("value1"),
+	})
+	assert.NoError(t, err, "Expected to write message to topic")
+	assert.NoError(t, w.Close())
+
+	tctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	r := NewReader(kafka.ReaderConfig{
+		Brokers: []string{"localhost:9092"},
+		GroupID: testGroupID,
+		Topic:   testTopic,
+	}, WithDataStreams())
+	msg, err := r.ReadMessage(tctx)
+	assert.NoError(t, err, "Expected to consume message")
+	r.Close()
+
+	_, ok := extractPathway(&msg)
+	assert.True(t, ok, "the consumed message should carry a pathway")
+
+	spans := mt.FinishedSpans()
+	assert.Len(t, spans, 2)
+	s0 := spans[0] // produce
+	assert.Equal(t, "Produce Topic "+testTopic, s0.Tag(ext.ResourceName))
+	s1 := spans[1] // consume
+	assert.NotNil(t, s1.Tag("pathway.hash"))
+	assert.NotNil(t, s1.Tag("pathway.ms_since_start"))
+	assert.NotNil(t, s1.Tag("pathway.ms_since_produce"))
 }
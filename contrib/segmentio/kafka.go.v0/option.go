@@ -16,6 +16,7 @@ type config struct {
 	consumerServiceName string
 	producerServiceName string
 	analyticsRate       float64
+	dataStreamsEnabled  bool
 }
 
 // An Option customizes the config.
@@ -70,3 +71,14 @@ func WithAnalyticsRate(rate float64) Option {
 		}
 	}
 }
+
+// WithDataStreams enables the propagation of Data Streams Monitoring pathways:
+// produced messages carry the pathway they belong to in their headers, and
+// consume spans are tagged with the pathway hash along with the time elapsed
+// since the pathway started and since the message was produced. Only
+// propagation is supported; no pathway stats are aggregated or sent to the agent.
+func WithDataStreams() Option {
+	return func(cfg *config) {
+		cfg.dataStreamsEnabled = true
+	}
+}
@@ -19,3 +19,10 @@ const (
 	// DBStatement records a database statement for the given database type.
 	DBStatement = "db.statement"
 )
+
+const (
+	// DBSystem indicates the database management system (DBMS) product being used.
+	DBSystem = "db.system"
+	// DBSystemPostgreSQL indicates that the database system is PostgreSQL.
+	DBSystemPostgreSQL = "postgresql"
+)
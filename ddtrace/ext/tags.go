@@ -52,6 +52,10 @@ const (
 	// See https://datadoghq.atlassian.net/wiki/spaces/APMINT/pages/2302444638/DD+TRACE+HEADER+TAGS
 	HTTPRequestHeaders = "http.request.headers"
 
+	// HTTPResponseHeaders sets the HTTP response headers partial tag
+	// This tag is meant to be composed, i.e http.response.headers.headerX, http.response.headers.headerY, etc...
+	HTTPResponseHeaders = "http.response.headers"
+
 	// SpanName is a pseudo-key for setting a span's operation name by means of
 	// a tag. It is mostly here to facilitate vendor-agnostic frameworks like Opentracing
 	// and OpenCensus.
@@ -105,4 +109,9 @@ const (
 
 	// RuntimeID is a tag that contains a unique id for this process.
 	RuntimeID = "runtime-id"
+
+	// Component specifies the name of the integration (library or framework)
+	// which created the span. It is used to break down the tracer's health
+	// metrics by integration.
+	Component = "component"
 )
@@ -8,6 +8,7 @@ package tracer
 import (
 	"runtime"
 	"runtime/debug"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -93,17 +94,85 @@ func (t *tracer) reportRuntimeMetrics(interval time.Duration) {
 	}
 }
 
+// reportHealthMetrics periodically reports metrics about the health of the tracer
+// at the given interval.
 func (t *tracer) reportHealthMetrics(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
-			t.config.statsd.Count("datadog.tracer.spans_started", atomic.SwapInt64(&t.spansStarted, 0), nil, 1)
-			t.config.statsd.Count("datadog.tracer.spans_finished", atomic.SwapInt64(&t.spansFinished, 0), nil, 1)
+			t.spansStarted.report(t.config.statsd, "datadog.tracer.spans_started")
+			t.spansFinished.report(t.config.statsd, "datadog.tracer.spans_finished")
 			t.config.statsd.Count("datadog.tracer.traces_dropped", atomic.SwapInt64(&t.tracesDropped, 0), []string{"reason:trace_too_large"}, 1)
+			t.config.statsd.Count("datadog.tracer.traces_dropped", atomic.SwapInt64(&t.tracesSampledOut, 0), []string{"reason:sampler"}, 1)
+			if w, ok := t.traceWriter.(*agentTraceWriter); ok {
+				size, traces := w.highWaterMarks()
+				t.config.statsd.Gauge("datadog.tracer.encoder.size_high_water_mark", float64(size), nil, 1)
+				t.config.statsd.Gauge("datadog.tracer.encoder.traces_high_water_mark", float64(traces), nil, 1)
+			}
 		case <-t.stop:
 			return
 		}
 	}
 }
+
+const (
+	// maxIntegrationCounts is the maximum number of integrations for which integrationCounts
+	// keeps a counter. Integration names come from span tags, so their number is unbounded.
+	maxIntegrationCounts = 100
+
+	// otherIntegration is the integration to which counts are accounted once
+	// maxIntegrationCounts is reached.
+	otherIntegration = "other"
+)
+
+// integrationCounts holds counters keyed by the name of the integration which
+// they are accounted to. It is safe for concurrent use.
+type integrationCounts struct {
+	m  sync.Map   // map[string]*int64
+	mu sync.Mutex // guards the creation of counters
+	n  int        // number of counters in m, guarded by mu
+}
+
+// add adds n to the counter of the given integration.
+func (c *integrationCounts) add(integration string, n int64) {
+	v, ok := c.m.Load(integration)
+	if !ok {
+		v = c.counter(integration)
+	}
+	atomic.AddInt64(v.(*int64), n)
+}
+
+// counter returns the counter of the given integration, creating it if needed. Once
+// maxIntegrationCounts is reached, the counter of otherIntegration is returned instead.
+func (c *integrationCounts) counter(integration string) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.m.Load(integration); ok {
+		return v
+	}
+	if c.n >= maxIntegrationCounts {
+		integration = otherIntegration
+		if v, ok := c.m.Load(integration); ok {
+			return v
+		}
+	}
+	v := new(int64)
+	c.m.Store(integration, v)
+	c.n++
+	return v
+}
+
+// report reports the value accumulated by each counter since the last report as
+// the metric name, tagged by integration, and resets them.
+func (c *integrationCounts) report(statsd statsdClient, name string) {
+	c.m.Range(func(k, v interface{}) bool {
+		var tags []string
+		if integration := k.(string); integration != "" {
+			tags = []string{"integration:" + integration}
+		}
+		statsd.Count(name, atomic.SwapInt64(v.(*int64), 0), tags, 1)
+		return true
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -283,6 +285,138 @@ func TestReportHealthMetrics(t *testing.T) {
 	assert.Equal(int64(0), counts["datadog.tracer.traces_dropped"])
 }
 
+func TestReportHealthMetricsIntegration(t *testing.T) {
+	assert := assert.New(t)
+	var tg testStatsdClient
+
+	defer func(old time.Duration) { statsInterval = old }(statsInterval)
+	statsInterval = time.Millisecond
+
+	tracer, _, flush, stop := startTestTracer(t, withStatsdClient(&tg))
+	defer stop()
+
+	root := tracer.StartSpan("http.request", Tag(ext.Component, "net/http"))
+	tracer.StartSpan("db.query", ChildOf(root.Context()), Tag(ext.Component, "database/sql")).Finish()
+	root.Finish()
+	flush(1)
+	tg.Wait(10, 1*time.Second)
+
+	started := make(map[string]int64)
+	for _, c := range tg.CountCalls() {
+		if c.name == "datadog.tracer.spans_started" && len(c.tags) == 1 {
+			started[c.tags[0]] += c.intVal
+		}
+	}
+	assert.Equal(int64(1), started["integration:net/http"])
+	assert.Equal(int64(1), started["integration:database/sql"])
+
+	var sizeReported bool
+	for _, c := range tg.GaugeCalls() {
+		if c.name == "datadog.tracer.encoder.size_high_water_mark" && c.floatVal > 0 {
+			sizeReported = true
+		}
+	}
+	assert.True(sizeReported)
+}
+
+func TestHealthMetricsDisabled(t *testing.T) {
+	var tg testStatsdClient
+
+	defer func(old time.Duration) { statsInterval = old }(statsInterval)
+	statsInterval = time.Nanosecond
+
+	tracer, _, flush, stop := startTestTracer(t, withStatsdClient(&tg), WithHealthMetrics(false))
+
+	tracer.StartSpan("operation").Finish()
+	flush(1)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	// neither the tracer nor its trace writer send any metric
+	assert.Empty(t, tg.CallNames())
+}
+
+func TestIntegrationCounts(t *testing.T) {
+	var (
+		c  integrationCounts
+		tg testStatsdClient
+	)
+	c.add("", 1)
+	c.add("net/http", 2)
+	c.add("net/http", 3)
+	c.report(&tg, "count")
+
+	calls := tg.CountCalls()
+	assert.Len(t, calls, 2)
+	for _, call := range calls {
+		if len(call.tags) == 0 {
+			assert.Equal(t, int64(1), call.intVal)
+		} else {
+			assert.Equal(t, []string{"integration:net/http"}, call.tags)
+			assert.Equal(t, int64(5), call.intVal)
+		}
+	}
+
+	// counters are reset after being reported
+	c.report(&tg, "count")
+	assert.Equal(t, int64(6), tg.Counts()["count"])
+}
+
+func TestIntegrationCountsLimit(t *testing.T) {
+	var c integrationCounts
+	for i := 0; i < maxIntegrationCounts+10; i++ {
+		c.add(fmt.Sprintf("integration-%d", i), 1)
+	}
+	v, ok := c.m.Load(otherIntegration)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), *v.(*int64))
+	_, ok = c.m.Load(fmt.Sprintf("integration-%d", maxIntegrationCounts))
+	assert.False(t, ok)
+	assert.Equal(t, maxIntegrationCounts, c.n)
+}
+
+func TestReportHealthMetricsSampler(t *testing.T) {
+	var tg testStatsdClient
+
+	defer func(old time.Duration) { statsInterval = old }(statsInterval)
+	statsInterval = time.Millisecond
+
+	tracer, _, _, stop := startTestTracer(t, withStatsdClient(&tg), WithSampler(NewRateSampler(0)))
+	defer stop()
+
+	tracer.StartSpan("operation", Tag(ext.Component, "net/http")).Finish()
+	// the integration is only taken from the start options, so that
+	// setting the component afterwards doesn't skew the counts
+	span := tracer.StartSpan("operation")
+	span.SetTag(ext.Component, "database/sql")
+	span.Finish()
+	tg.Wait(10, 1*time.Second)
+
+	var dropped int64
+	started := make(map[string]int64)
+	finished := make(map[string]int64)
+	for _, c := range tg.CountCalls() {
+		var integration string
+		if len(c.tags) == 1 {
+			integration = c.tags[0]
+		}
+		switch c.name {
+		case "datadog.tracer.traces_dropped":
+			if integration == "reason:sampler" {
+				dropped += c.intVal
+			}
+		case "datadog.tracer.spans_started":
+			started[integration] += c.intVal
+		case "datadog.tracer.spans_finished":
+			finished[integration] += c.intVal
+		}
+	}
+	assert.Equal(t, int64(2), dropped)
+	assert.Equal(t, int64(1), started["integration:net/http"])
+	assert.Equal(t, int64(1), started[""])
+	assert.Equal(t, started, finished)
+}
+
 func TestTracerMetrics(t *testing.T) {
 	assert := assert.New(t)
 	var tg testStatsdClient
@@ -110,6 +110,14 @@ type config struct {
 	// runtimeMetrics specifies whether collection of runtime metrics is enabled.
 	runtimeMetrics bool
 
+	// baggageTagKeys specifies the keys of the baggage items which are copied onto
+	// spans as tags.
+	baggageTagKeys []string
+
+	// healthMetrics specifies whether the tracer periodically reports metrics about
+	// its own health (spans started and finished, traces dropped, payload sizes).
+	healthMetrics bool
+
 	// dogstatsdAddr specifies the address to connect for sending metrics to the
 	// Datadog Agent. If not set, it defaults to "localhost:8125" or to the
 	// combination of the environment variables DD_AGENT_HOST and DD_DOGSTATSD_PORT.
@@ -228,6 +236,10 @@ func newConfig(opts ...StartOption) *config {
 	}
 	c.logStartup = internal.BoolEnv("DD_TRACE_STARTUP_LOGS", true)
 	c.runtimeMetrics = internal.BoolEnv("DD_RUNTIME_METRICS_ENABLED", false)
+	c.healthMetrics = internal.BoolEnv("DD_TRACE_HEALTH_METRICS_ENABLED", true)
+	if v := os.Getenv("DD_TRACE_BAGGAGE_TAG_KEYS"); v != "" {
+		WithBaggageTagKeys(strings.Split(v, ",")...)(c)
+	}
 	c.debug = internal.BoolEnv("DD_TRACE_DEBUG", false)
 	c.enabled = internal.BoolEnv("DD_TRACE_ENABLED", true)
 	c.profilerEndpoints = internal.BoolEnv(traceprof.EndpointEnvVar, true)
@@ -244,6 +256,7 @@ func newConfig(opts ...StartOption) *config {
 			}
 		}
 	}
+	globalconfig.SetEnv(c.env)
 	if c.version == "" {
 		if v, ok := c.globalTags["version"]; ok {
 			if ver, ok := v.(string); ok {
@@ -277,6 +290,7 @@ func newConfig(opts ...StartOption) *config {
 		}
 		c.propagator = NewPropagator(&PropagatorConfig{
 			MaxTagsHeaderLen: max,
+			W3CBaggage:       internal.BoolEnv("DD_TRACE_W3C_BAGGAGE_ENABLED", false),
 		})
 	}
 	if c.logger != nil {
@@ -464,6 +478,15 @@ func statsTags(c *config) []string {
 	return tags
 }
 
+// healthStatsd returns the statsd client used to send the tracer's health metrics,
+// which is a no-op client when they are disabled.
+func (c *config) healthStatsd() statsdClient {
+	if !c.healthMetrics || c.statsd == nil {
+		return &statsd.NoOpClient{}
+	}
+	return c.statsd
+}
+
 // withNoopStats is used for testing to disable statsd client
 func withNoopStats() StartOption {
 	return func(c *config) {
@@ -654,13 +677,51 @@ func WithRuntimeMetrics() StartOption {
 	}
 }
 
+// WithBaggageTagKeys specifies the keys of the baggage items which are copied onto spans
+// as "baggage.<key>" tags, making them searchable. Items are copied when a span is started
+// with baggage inherited from its parent (local or propagated), and whenever they are set
+// using SetBaggageItem. The keys can also be set using the DD_TRACE_BAGGAGE_TAG_KEYS
+// environment variable, as a comma-separated list, which this option overrides.
+func WithBaggageTagKeys(keys ...string) StartOption {
+	return func(cfg *config) {
+		cfg.baggageTagKeys = nil
+		for _, k := range keys {
+			if k = strings.TrimSpace(k); k != "" {
+				cfg.baggageTagKeys = append(cfg.baggageTagKeys, k)
+			}
+		}
+	}
+}
+
+// isBaggageTagKey reports whether the baggage item with the given key should be copied
+// onto spans as a tag.
+func (c *config) isBaggageTagKey(key string) bool {
+	for _, k := range c.baggageTagKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// WithHealthMetrics enables or disables the tracer's health metrics, such as the number
+// of spans started and finished, the number of traces flushed or dropped and the duration
+// of the flushes. Span counts are tagged with the integration that created them, as set
+// by the ext.Component tag. Health metrics are enabled by default, and can also be disabled
+// by setting the DD_TRACE_HEALTH_METRICS_ENABLED environment variable to false.
+func WithHealthMetrics(enabled bool) StartOption {
+	return func(cfg *config) {
+		cfg.healthMetrics = enabled
+	}
+}
+
 // WithDogstatsdAddress specifies the address to connect to for sending metrics to the Datadog
 // Agent. It should be a "host:port" string, or the path to a unix domain socket.If not set, it
 // attempts to determine the address of the statsd service according to the following rules:
 //   1. Look for /var/run/datadog/dsd.socket and use it if present. IF NOT, continue to #2.
 //   2. The host is determined by DD_AGENT_HOST, and defaults to "localhost"
 //   3. The port is retrieved from the agent. If not present, it is determined by DD_DOGSTATSD_PORT, and defaults to 8125
-// This option is in effect when WithRuntimeMetrics is enabled.
+// This option is in effect when WithRuntimeMetrics or WithHealthMetrics are enabled.
 func WithDogstatsdAddress(addr string) StartOption {
 	return func(cfg *config) {
 		cfg.dogstatsdAddr = addr
@@ -316,9 +316,11 @@ func TestTracerOptionsDefaults(t *testing.T) {
 		defer os.Unsetenv("DD_ENV")
 		assert := assert.New(t)
 		env := "production"
+		defer globalconfig.SetEnv("")
 		tracer := newTracer(WithEnv(env))
 		c := tracer.config
 		assert.Equal(env, c.env)
+		assert.Equal(env, globalconfig.Env())
 	})
 
 	t.Run("trace_enabled", func(t *testing.T) {
@@ -830,6 +832,33 @@ func TestGlobalTag(t *testing.T) {
 	assert.Contains(t, statsTags(&c), "k:v")
 }
 
+func TestWithBaggageTagKeys(t *testing.T) {
+	t.Run("option", func(t *testing.T) {
+		var c config
+		WithBaggageTagKeys("a", " b ", "")(&c)
+		assert.Equal(t, []string{"a", "b"}, c.baggageTagKeys)
+		assert.True(t, c.isBaggageTagKey("b"))
+		assert.False(t, c.isBaggageTagKey("c"))
+	})
+
+	t.Run("env", func(t *testing.T) {
+		os.Setenv("DD_TRACE_BAGGAGE_TAG_KEYS", "user.id, session.id")
+		defer os.Unsetenv("DD_TRACE_BAGGAGE_TAG_KEYS")
+
+		c := newConfig()
+		assert.Equal(t, []string{"user.id", "session.id"}, c.baggageTagKeys)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		os.Setenv("DD_TRACE_BAGGAGE_TAG_KEYS", "user.id, session.id")
+		defer os.Unsetenv("DD_TRACE_BAGGAGE_TAG_KEYS")
+
+		c := newConfig(WithBaggageTagKeys("team"))
+		assert.Equal(t, []string{"team"}, c.baggageTagKeys)
+		assert.False(t, c.isBaggageTagKey("user.id"))
+	})
+}
+
 func TestWithHostname(t *testing.T) {
 	t.Run("WithHostname", func(t *testing.T) {
 		assert := assert.New(t)
@@ -75,6 +75,7 @@ type span struct {
 	Error    int32              `msg:"error"`             // error status of the span; 0 means no errors
 
 	noDebugStack bool         `msg:"-"` // disables debug stack traces
+	integration  string       `msg:"-"` // integration which created the span, from the ext.Component start option
 	finished     bool         `msg:"-"` // true if the span has been submitted to a tracer.
 	context      *spanContext `msg:"-"` // span propagation context
 
@@ -94,6 +95,9 @@ func (s *span) Context() ddtrace.SpanContext { return s.context }
 // care as it adds extra load onto your tracing layer.
 func (s *span) SetBaggageItem(key, val string) {
 	s.context.setBaggageItem(key, val)
+	if t, ok := internal.GetGlobalTracer().(*tracer); ok && t.config.isBaggageTagKey(key) {
+		s.SetTag(keyBaggageTagPrefix+key, val)
+	}
 }
 
 // BaggageItem gets the value for a baggage item given its key. Returns the
@@ -580,4 +584,10 @@ const (
 	keyTopLevel = "_dd.top_level"
 	// keyPropagationError holds any error from propagated trace tags (if any)
 	keyPropagationError = "_dd.propagation_error"
+	// keyProfilingEnabled is set on local root spans when the profiler is
+	// running, which lets the backend link traces to profiles (code hotspots).
+	keyProfilingEnabled = "_dd.profiling.enabled"
+	// keyBaggageTagPrefix prefixes the tags holding baggage items copied onto spans
+	// (see WithBaggageTagKeys).
+	keyBaggageTagPrefix = "baggage."
 )
@@ -273,7 +273,7 @@ func (t *trace) push(sp *span) {
 	}
 	t.spans = append(t.spans, sp)
 	if haveTracer {
-		atomic.AddInt64(&tr.spansStarted, 1)
+		tr.spansStarted.add(sp.integration, 1)
 	}
 }
 
@@ -323,9 +323,12 @@ func (t *trace) finishedOne(s *span) {
 		return
 	}
 	// we have a tracer that can receive completed traces.
-	atomic.AddInt64(&tr.spansFinished, int64(len(t.spans)))
+	for _, s := range t.spans {
+		tr.spansFinished.add(s.integration, 1)
+	}
 	sd := samplingDecision(atomic.LoadInt64((*int64)(&t.samplingDecision)))
 	if sd != decisionKeep {
+		atomic.AddInt64(&tr.tracesSampledOut, 1)
 		if p, ok := t.samplingPriorityLocked(); ok && p == ext.PriorityAutoReject {
 			atomic.AddUint64(&tr.droppedP0Spans, uint64(len(t.spans)))
 			atomic.AddUint64(&tr.droppedP0Traces, 1)
@@ -14,7 +14,6 @@ import (
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
 
-	"github.com/DataDog/datadog-go/v5/statsd"
 	"github.com/DataDog/sketches-go/ddsketch"
 	"google.golang.org/protobuf/proto"
 )
@@ -121,12 +120,10 @@ func (c *concentrator) runFlusher(tick <-chan time.Time) {
 	}
 }
 
-// statsd returns any tracer configured statsd client, or a no-op.
+// statsd returns any tracer configured statsd client, or a no-op when none is
+// configured or health metrics are disabled.
 func (c *concentrator) statsd() statsdClient {
-	if c.cfg.statsd == nil {
-		return &statsd.NoOpClient{}
-	}
-	return c.cfg.statsd
+	return c.cfg.healthStatsd()
 }
 
 // runIngester runs the loop which accepts incoming data on the concentrator's In
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -95,6 +96,17 @@ const originHeader = "x-datadog-origin"
 // traceTagsHeader holds the propagated trace tags
 const traceTagsHeader = "x-datadog-tags"
 
+// w3cBaggageHeader specifies the name of the header holding baggage items in the
+// W3C format. See https://www.w3.org/TR/baggage/
+const w3cBaggageHeader = "baggage"
+
+const (
+	// w3cBaggageMaxMembers and w3cBaggageMaxBytes are the limits on the number of list
+	// members and on the size of the W3C baggage header value.
+	w3cBaggageMaxMembers = 180
+	w3cBaggageMaxBytes   = 8192
+)
+
 // propagationExtractMaxSize limits the total size of incoming propagated tags to parse
 const propagationExtractMaxSize = 512
 
@@ -123,6 +135,11 @@ type PropagatorConfig struct {
 	// B3 specifies if B3 headers should be added for trace propagation.
 	// See https://github.com/openzipkin/b3-propagation
 	B3 bool
+
+	// W3CBaggage specifies whether baggage items should also be propagated using the
+	// W3C baggage header, in addition to the prefixed headers (see BaggagePrefix).
+	// See https://www.w3.org/TR/baggage/
+	W3CBaggage bool
 }
 
 // NewPropagator returns a new propagator which uses TextMap to inject
@@ -263,8 +280,19 @@ func (p *propagator) injectTextMap(spanCtx ddtrace.SpanContext, writer TextMapWr
 	}
 	// propagate OpenTracing baggage
 	for k, v := range ctx.baggage {
+		if hasCTL(v) {
+			// baggage extracted from the W3C header may hold decoded control
+			// characters, which are not allowed in header values
+			log.Debug("Won't propagate baggage item %q: value contains control characters.", k)
+			continue
+		}
 		writer.Set(p.cfg.BaggagePrefix+k, v)
 	}
+	if p.cfg.W3CBaggage {
+		if s := marshalW3CBaggage(ctx); s != "" {
+			writer.Set(w3cBaggageHeader, s)
+		}
+	}
 	if p.cfg.MaxTagsHeaderLen <= 0 {
 		return nil
 	}
@@ -339,6 +367,10 @@ func (p *propagator) extractTextMap(reader TextMapReader) (ddtrace.SpanContext,
 			ctx.origin = v
 		case traceTagsHeader:
 			unmarshalPropagatingTags(&ctx, v)
+		case w3cBaggageHeader:
+			if p.cfg.W3CBaggage {
+				unmarshalW3CBaggage(&ctx, v)
+			}
 		default:
 			if strings.HasPrefix(key, p.cfg.BaggagePrefix) {
 				ctx.setBaggageItem(strings.TrimPrefix(key, p.cfg.BaggagePrefix), v)
@@ -355,6 +387,122 @@ func (p *propagator) extractTextMap(reader TextMapReader) (ddtrace.SpanContext,
 	return &ctx, nil
 }
 
+// marshalW3CBaggage marshals the baggage items of ctx as a W3C baggage header value.
+// Values are percent-encoded, and items whose key is not a valid token are skipped. Items
+// are dropped once the header would exceed w3cBaggageMaxMembers list members or
+// w3cBaggageMaxBytes bytes.
+func marshalW3CBaggage(ctx *spanContext) string {
+	var (
+		sb strings.Builder
+		n  int
+	)
+	ctx.ForeachBaggageItem(func(k, v string) bool {
+		if !isToken(k) {
+			return true
+		}
+		member := k + "=" + escapeW3CBaggageValue(v)
+		if n == w3cBaggageMaxMembers || sb.Len()+len(member)+1 > w3cBaggageMaxBytes {
+			log.Warn("Won't propagate all baggage items: maximum %s header size reached.", w3cBaggageHeader)
+			return false
+		}
+		if n > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(member)
+		n++
+		return true
+	})
+	return sb.String()
+}
+
+// escapeW3CBaggageValue percent-encodes all the bytes of s which are not baggage-octets,
+// as well as '%'.
+func escapeW3CBaggageValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isW3CBaggageValueChar(c) {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(hex[c>>4])
+		sb.WriteByte(hex[c&0xf])
+	}
+	return sb.String()
+}
+
+// isToken reports whether s is a token as defined by RFC 7230, which W3C baggage keys
+// must be.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			continue
+		}
+		if strings.IndexByte("!#$%&'*+-.^_`|~", c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hasCTL reports whether s contains control characters other than horizontal tabs.
+func hasCTL(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < 0x20 && c != '\t') || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// isW3CBaggageValueChar reports whether c can be left unescaped in a baggage value,
+// which must be made of baggage-octets. '%' is escaped so that values can be decoded.
+func isW3CBaggageValueChar(c byte) bool {
+	switch c {
+	case '"', ',', ';', '\\', '%':
+		return false
+	}
+	return 0x21 <= c && c <= 0x7e
+}
+
+// unmarshalW3CBaggage sets the baggage items found in the W3C baggage header value v
+// on ctx. Metadata properties are ignored, as are malformed list members and members
+// whose key is not a token. Keys are not percent-decoded. Header values
+// exceeding w3cBaggageMaxMembers list members or w3cBaggageMaxBytes bytes are ignored.
+func unmarshalW3CBaggage(ctx *spanContext, v string) {
+	members := strings.Split(v, ",")
+	if len(v) > w3cBaggageMaxBytes || len(members) > w3cBaggageMaxMembers {
+		log.Warn("Did not extract %s, size limit exceeded.", w3cBaggageHeader)
+		return
+	}
+	for _, member := range members {
+		if i := strings.IndexByte(member, ';'); i >= 0 {
+			// drop the properties
+			member = member[:i]
+		}
+		kv := strings.SplitN(member, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(kv[0])
+		if !isToken(key) {
+			continue
+		}
+		val, err := url.PathUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			continue
+		}
+		ctx.setBaggageItem(key, val)
+	}
+}
+
 // unmarshalPropagatingTags unmarshals tags from v into ctx
 func unmarshalPropagatingTags(ctx *spanContext, v string) {
 	if ctx.trace == nil {
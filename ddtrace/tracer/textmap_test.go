@@ -549,6 +549,145 @@ func TestB3(t *testing.T) {
 	})
 }
 
+func TestW3CBaggage(t *testing.T) {
+	t.Run("inject", func(t *testing.T) {
+		assert := assert.New(t)
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		root := tracer.StartSpan("web.request").(*span)
+		root.SetBaggageItem("user.id", "a,b=c")
+		headers := TextMapCarrier(map[string]string{})
+		err := tracer.Inject(root.Context(), headers)
+		assert.Nil(err)
+		assert.Equal("user.id=a%2Cb=c", headers[w3cBaggageHeader])
+		assert.Equal("a,b=c", headers[DefaultBaggageHeaderPrefix+"user.id"])
+	})
+
+	t.Run("inject-escaping", func(t *testing.T) {
+		assert := assert.New(t)
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		root := tracer.StartSpan("web.request").(*span)
+		root.SetBaggageItem("a=b;c", "x")
+		root.SetBaggageItem("item", "100% \"ok\"")
+		headers := TextMapCarrier(map[string]string{})
+		err := tracer.Inject(root.Context(), headers)
+		assert.Nil(err)
+		// keys which are not tokens are skipped
+		assert.Equal("item=100%25%20%22ok%22", headers[w3cBaggageHeader])
+
+		sctx, err := tracer.Extract(headers)
+		assert.Nil(err)
+		assert.Equal("100% \"ok\"", sctx.(*spanContext).baggageItem("item"))
+	})
+
+	t.Run("inject-limits", func(t *testing.T) {
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		root := tracer.StartSpan("web.request").(*span)
+		for i := 0; i < w3cBaggageMaxMembers+10; i++ {
+			root.SetBaggageItem(fmt.Sprintf("key%d", i), "x")
+		}
+		headers := TextMapCarrier(map[string]string{})
+		err := tracer.Inject(root.Context(), headers)
+		assert.Nil(t, err)
+		assert.Len(t, strings.Split(headers[w3cBaggageHeader], ","), w3cBaggageMaxMembers)
+
+		root = tracer.StartSpan("web.request").(*span)
+		for i := 0; i < 10; i++ {
+			root.SetBaggageItem(fmt.Sprintf("key%d", i), strings.Repeat("x", 1000))
+		}
+		headers = TextMapCarrier(map[string]string{})
+		err = tracer.Inject(root.Context(), headers)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(headers[w3cBaggageHeader]), w3cBaggageMaxBytes)
+		assert.Len(t, strings.Split(headers[w3cBaggageHeader], ","), 8)
+	})
+
+	t.Run("inject-disabled", func(t *testing.T) {
+		tracer := newTracer()
+		root := tracer.StartSpan("web.request").(*span)
+		root.SetBaggageItem("item", "x")
+		headers := TextMapCarrier(map[string]string{})
+		err := tracer.Inject(root.Context(), headers)
+		assert.Nil(t, err)
+		assert.NotContains(t, headers, w3cBaggageHeader)
+	})
+
+	t.Run("extract", func(t *testing.T) {
+		assert := assert.New(t)
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		headers := TextMapCarrier(map[string]string{
+			DefaultTraceIDHeader:  "1",
+			DefaultParentIDHeader: "1",
+			w3cBaggageHeader:      "user.id=a%2Cb, team = web ;prop=1,invalid,=x,item=%zz,user id=x,k%20=y",
+		})
+		sctx, err := tracer.Extract(headers)
+		assert.Nil(err)
+		ctx, ok := sctx.(*spanContext)
+		assert.True(ok)
+		// keys are not percent-decoded
+		assert.Equal(map[string]string{"user.id": "a,b", "team": "web", "k%20": "y"}, ctx.baggage)
+	})
+
+	t.Run("extract-inject", func(t *testing.T) {
+		assert := assert.New(t)
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		sctx, err := tracer.Extract(TextMapCarrier(map[string]string{
+			DefaultTraceIDHeader:  "1",
+			DefaultParentIDHeader: "1",
+			w3cBaggageHeader:      "k=a%0Ab,item=x",
+		}))
+		assert.Nil(err)
+		headers := TextMapCarrier(map[string]string{})
+		err = tracer.Inject(sctx, headers)
+		assert.Nil(err)
+		// values holding control characters can't be set as header values
+		assert.NotContains(headers, DefaultBaggageHeaderPrefix+"k")
+		assert.Equal("x", headers[DefaultBaggageHeaderPrefix+"item"])
+		assertTraceTags(t, "k=a%0Ab,item=x", headers[w3cBaggageHeader])
+	})
+
+	t.Run("extract-limits", func(t *testing.T) {
+		tracer := newTracer(WithPropagator(NewPropagator(&PropagatorConfig{W3CBaggage: true})))
+		members := make([]string, w3cBaggageMaxMembers+1)
+		for i := range members {
+			members[i] = fmt.Sprintf("key%d=x", i)
+		}
+		for _, v := range []string{
+			strings.Join(members, ","),
+			"item=" + strings.Repeat("x", w3cBaggageMaxBytes),
+		} {
+			headers := TextMapCarrier(map[string]string{
+				DefaultTraceIDHeader:  "1",
+				DefaultParentIDHeader: "1",
+				w3cBaggageHeader:      v,
+			})
+			sctx, err := tracer.Extract(headers)
+			assert.Nil(t, err)
+			assert.Empty(t, sctx.(*spanContext).baggage)
+		}
+	})
+
+	t.Run("extract-disabled", func(t *testing.T) {
+		tracer := newTracer()
+		headers := TextMapCarrier(map[string]string{
+			DefaultTraceIDHeader:  "1",
+			DefaultParentIDHeader: "1",
+			w3cBaggageHeader:      "item=x",
+		})
+		sctx, err := tracer.Extract(headers)
+		assert.Nil(t, err)
+		assert.Equal(t, "", sctx.(*spanContext).baggageItem("item"))
+	})
+
+	t.Run("env", func(t *testing.T) {
+		os.Setenv("DD_TRACE_W3C_BAGGAGE_ENABLED", "true")
+		defer os.Unsetenv("DD_TRACE_W3C_BAGGAGE_ENABLED")
+
+		p, ok := newConfig().propagator.(*chainedPropagator)
+		assert.True(t, ok)
+		assert.True(t, p.injectors[0].(*propagator).cfg.W3CBaggage)
+	})
+}
+
 func assertTraceTags(t *testing.T, expected, actual string) {
 	assert.ElementsMatch(t, strings.Split(expected, ","), strings.Split(actual, ","))
 }
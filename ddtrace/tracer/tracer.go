@@ -67,9 +67,11 @@ type tracer struct {
 	// pid of the process
 	pid string
 
-	// These integers track metrics about spans and traces as they are started,
-	// finished, and dropped
-	spansStarted, spansFinished, tracesDropped int64
+	// These track metrics about spans and traces as they are started, finished,
+	// and dropped. Span counts are kept per integration.
+	spansStarted, spansFinished integrationCounts
+	tracesDropped               int64 // traces dropped for being too large
+	tracesSampledOut            int64 // traces dropped by the sampler
 
 	// Records the number of dropped P0 traces and spans.
 	droppedP0Traces, droppedP0Spans uint64
@@ -216,7 +218,7 @@ func newUnstartedTracer(opts ...StartOption) *tracer {
 func newTracer(opts ...StartOption) *tracer {
 	t := newUnstartedTracer(opts...)
 	c := t.config
-	t.config.statsd.Incr("datadog.tracer.started", nil, 1)
+	t.config.healthStatsd().Incr("datadog.tracer.started", nil, 1)
 	if c.runtimeMetrics {
 		log.Debug("Runtime metrics enabled.")
 		t.wg.Add(1)
@@ -236,11 +238,13 @@ func newTracer(opts ...StartOption) *tracer {
 		}
 		t.worker(tick)
 	}()
-	t.wg.Add(1)
-	go func() {
-		defer t.wg.Done()
-		t.reportHealthMetrics(statsInterval)
-	}()
+	if c.healthMetrics {
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			t.reportHealthMetrics(statsInterval)
+		}()
+	}
 	t.stats.Start()
 	appsec.Start()
 	return t
@@ -278,11 +282,11 @@ func (t *tracer) worker(tick <-chan time.Time) {
 			t.traceWriter.add(trace)
 
 		case <-tick:
-			t.config.statsd.Incr("datadog.tracer.flush_triggered", []string{"reason:scheduled"}, 1)
+			t.config.healthStatsd().Incr("datadog.tracer.flush_triggered", []string{"reason:scheduled"}, 1)
 			t.traceWriter.flush()
 
 		case done := <-t.flush:
-			t.config.statsd.Incr("datadog.tracer.flush_triggered", []string{"reason:invoked"}, 1)
+			t.config.healthStatsd().Incr("datadog.tracer.flush_triggered", []string{"reason:invoked"}, 1)
 			t.traceWriter.flush()
 			// TODO(x): In reality, the traceWriter.flush() call is not synchronous
 			// when using the agent traceWriter. However, this functionnality is used
@@ -394,10 +398,18 @@ func (t *tracer) StartSpan(operationName string, options ...ddtrace.StartSpanOpt
 			}
 		}
 	}
+	if v, ok := opts.Tags[ext.Component].(string); ok {
+		// set before the span is pushed into its trace, so that it can be
+		// accounted to its integration.
+		span.integration = v
+	}
 	span.context = newSpanContext(span, context)
 	if context == nil || context.span == nil {
 		// this is either a root span or it has a remote parent, we should add the PID.
 		span.setMeta(ext.Pid, t.pid)
+		if traceprof.IsProfilerEnabled() {
+			span.setMetric(keyProfilingEnabled, 1)
+		}
 		if _, ok := opts.Tags[ext.ServiceName]; !ok && t.config.runtimeMetrics {
 			// this is a root span in the global service; runtime metrics should
 			// be linked to it:
@@ -412,6 +424,12 @@ func (t *tracer) StartSpan(operationName string, options ...ddtrace.StartSpanOpt
 	for k, v := range t.config.globalTags {
 		span.SetTag(k, v)
 	}
+	// copy the selected baggage items inherited from the parent
+	for _, k := range t.config.baggageTagKeys {
+		if v := span.context.baggageItem(k); v != "" {
+			span.setMeta(keyBaggageTagPrefix+k, v)
+		}
+	}
 	if t.config.serviceMappings != nil {
 		if newSvc, ok := t.config.serviceMappings[span.Service]; ok {
 			span.Service = newSvc
@@ -486,7 +504,7 @@ func spanResourcePIISafe(s *span) bool {
 func (t *tracer) Stop() {
 	t.stopOnce.Do(func() {
 		close(t.stop)
-		t.config.statsd.Incr("datadog.tracer.stopped", nil, 1)
+		t.config.healthStatsd().Incr("datadog.tracer.stopped", nil, 1)
 	})
 	t.stats.Stop()
 	t.wg.Wait()
@@ -28,6 +28,7 @@ import (
 	maininternal "gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/traceprof"
 )
 
 func (t *tracer) newEnvSpan(service, env string) *span {
@@ -257,6 +258,22 @@ func TestTracerStartSpan(t *testing.T) {
 		assert.Equal(t, "-4", span.context.trace.propagatingTags[keyDecisionMaker])
 	})
 
+	t.Run("profiling", func(t *testing.T) {
+		tracer := newTracer()
+		defer tracer.Stop()
+		root := tracer.StartSpan("web.request").(*span)
+		_, ok := root.Metrics[keyProfilingEnabled]
+		assert.False(t, ok)
+
+		traceprof.SetProfilerEnabled(true)
+		defer traceprof.SetProfilerEnabled(false)
+		root = tracer.StartSpan("web.request").(*span)
+		child := tracer.StartSpan("db.query", ChildOf(root.Context())).(*span)
+		assert.Equal(t, 1.0, root.Metrics[keyProfilingEnabled])
+		_, ok = child.Metrics[keyProfilingEnabled]
+		assert.False(t, ok)
+	})
+
 	t.Run("name", func(t *testing.T) {
 		tracer := newTracer()
 		defer tracer.Stop()
@@ -616,6 +633,29 @@ func TestTracerBaggageImmutability(t *testing.T) {
 	assert.Equal("changed!", childContext.baggage["key"])
 }
 
+func TestTracerBaggageTagKeys(t *testing.T) {
+	assert := assert.New(t)
+	tracer, _, _, stop := startTestTracer(t, WithBaggageTagKeys("user.id"))
+	defer stop()
+
+	root := tracer.StartSpan("web.request").(*span)
+	root.SetBaggageItem("user.id", "123")
+	root.SetBaggageItem("other", "x")
+	assert.Equal("123", root.Meta["baggage.user.id"])
+	assert.NotContains(root.Meta, "baggage.other")
+
+	child := tracer.StartSpan("db.query", ChildOf(root.Context())).(*span)
+	assert.Equal("123", child.Meta["baggage.user.id"])
+	assert.NotContains(child.Meta, "baggage.other")
+
+	headers := TextMapCarrier(map[string]string{})
+	assert.Nil(tracer.Inject(root.Context(), headers))
+	sctx, err := tracer.Extract(headers)
+	assert.Nil(err)
+	remote := tracer.StartSpan("remote", ChildOf(sctx)).(*span)
+	assert.Equal("123", remote.Meta["baggage.user.id"])
+}
+
 func TestTracerSpanTags(t *testing.T) {
 	tracer := newTracer()
 	defer tracer.Stop()
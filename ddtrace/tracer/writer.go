@@ -14,6 +14,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
@@ -31,6 +32,11 @@ type traceWriter interface {
 }
 
 type agentTraceWriter struct {
+	// sizeHighWater and tracesHighWater hold the maximum size in bytes and number of
+	// traces reached by the payload since they were last read by highWaterMarks.
+	// They are accessed atomically and kept first in the struct for alignment.
+	sizeHighWater, tracesHighWater int64
+
 	// config holds the tracer configuration
 	config *config
 
@@ -59,17 +65,19 @@ func newAgentTraceWriter(c *config, s *prioritySampler) *agentTraceWriter {
 
 func (h *agentTraceWriter) add(trace []*span) {
 	if err := h.payload.push(trace); err != nil {
-		h.config.statsd.Incr("datadog.tracer.traces_dropped", []string{"reason:encoding_error"}, 1)
+		h.config.healthStatsd().Incr("datadog.tracer.traces_dropped", []string{"reason:encoding_error"}, 1)
 		log.Error("Error encoding msgpack: %v", err)
 	}
+	storeMaxInt64(&h.sizeHighWater, int64(h.payload.size()))
+	storeMaxInt64(&h.tracesHighWater, int64(h.payload.itemCount()))
 	if h.payload.size() > payloadSizeLimit {
-		h.config.statsd.Incr("datadog.tracer.flush_triggered", []string{"reason:size"}, 1)
+		h.config.healthStatsd().Incr("datadog.tracer.flush_triggered", []string{"reason:size"}, 1)
 		h.flush()
 	}
 }
 
 func (h *agentTraceWriter) stop() {
-	h.config.statsd.Incr("datadog.tracer.flush_triggered", []string{"reason:shutdown"}, 1)
+	h.config.healthStatsd().Incr("datadog.tracer.flush_triggered", []string{"reason:shutdown"}, 1)
 	h.flush()
 	h.wg.Wait()
 }
@@ -87,24 +95,40 @@ func (h *agentTraceWriter) flush() {
 		defer func(start time.Time) {
 			<-h.climit
 			h.wg.Done()
-			h.config.statsd.Timing("datadog.tracer.flush_duration", time.Since(start), nil, 1)
+			h.config.healthStatsd().Timing("datadog.tracer.flush_duration", time.Since(start), nil, 1)
 		}(time.Now())
 		size, count := p.size(), p.itemCount()
 		log.Debug("Sending payload: size: %d traces: %d\n", size, count)
 		rc, err := h.config.transport.send(p)
 		if err != nil {
-			h.config.statsd.Count("datadog.tracer.traces_dropped", int64(count), []string{"reason:send_failed"}, 1)
+			h.config.healthStatsd().Count("datadog.tracer.traces_dropped", int64(count), []string{"reason:send_failed"}, 1)
 			log.Error("lost %d traces: %v", count, err)
 		} else {
-			h.config.statsd.Count("datadog.tracer.flush_bytes", int64(size), nil, 1)
-			h.config.statsd.Count("datadog.tracer.flush_traces", int64(count), nil, 1)
+			h.config.healthStatsd().Count("datadog.tracer.flush_bytes", int64(size), nil, 1)
+			h.config.healthStatsd().Count("datadog.tracer.flush_traces", int64(count), nil, 1)
 			if err := h.prioritySampling.readRatesJSON(rc); err != nil {
-				h.config.statsd.Incr("datadog.tracer.decode_error", nil, 1)
+				h.config.healthStatsd().Incr("datadog.tracer.decode_error", nil, 1)
 			}
 		}
 	}(oldp)
 }
 
+// highWaterMarks returns the maximum size in bytes and number of traces reached by
+// the payload since the last call.
+func (h *agentTraceWriter) highWaterMarks() (size, traces int64) {
+	return atomic.SwapInt64(&h.sizeHighWater, 0), atomic.SwapInt64(&h.tracesHighWater, 0)
+}
+
+// storeMaxInt64 atomically stores v into addr if it is greater than the value it holds.
+func storeMaxInt64(addr *int64, v int64) {
+	for {
+		old := atomic.LoadInt64(addr)
+		if v <= old || atomic.CompareAndSwapInt64(addr, old, v) {
+			return
+		}
+	}
+}
+
 // logWriter specifies the output target of the logTraceWriter; replaced in tests.
 var logWriter io.Writer = os.Stdout
 
@@ -290,7 +314,7 @@ func (h *logTraceWriter) add(trace []*span) {
 		n, err := h.writeTrace(trace)
 		if err != nil {
 			log.Error("Lost a trace: %s", err.cause)
-			h.config.statsd.Count("datadog.tracer.traces_dropped", 1, []string{"reason:" + err.dropReason}, 1)
+			h.config.healthStatsd().Count("datadog.tracer.traces_dropped", 1, []string{"reason:" + err.dropReason}, 1)
 			return
 		}
 		trace = trace[n:]
@@ -303,7 +327,7 @@ func (h *logTraceWriter) add(trace []*span) {
 }
 
 func (h *logTraceWriter) stop() {
-	h.config.statsd.Incr("datadog.tracer.flush_triggered", []string{"reason:shutdown"}, 1)
+	h.config.healthStatsd().Incr("datadog.tracer.flush_triggered", []string{"reason:shutdown"}, 1)
 	h.flush()
 }
 
@@ -12,6 +12,9 @@ require (
 	github.com/aws/aws-sdk-go v1.34.28
 	github.com/aws/aws-sdk-go-v2 v1.0.0
 	github.com/aws/aws-sdk-go-v2/config v1.0.0
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.0.0
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.0.0
+	github.com/aws/aws-sdk-go-v2/service/sns v1.0.0
 	github.com/aws/aws-sdk-go-v2/service/sqs v1.0.0
 	github.com/aws/smithy-go v1.11.0
 	github.com/bradfitz/gomemcache v0.0.0-20220106215444-fb4bf637b56d
@@ -55,6 +58,7 @@ require (
 	github.com/hashicorp/vault/api v1.1.0
 	github.com/hashicorp/vault/sdk v0.1.14-0.20200519221838-e0cfd64bc267
 	github.com/jackc/pgx/v4 v4.14.0
+	github.com/jackc/pgx/v5 v5.0.0
 	github.com/jinzhu/gorm v1.9.10
 	github.com/jinzhu/now v1.1.3 // indirect
 	github.com/jmoiron/sqlx v1.2.0
@@ -73,7 +77,7 @@ require (
 	github.com/pierrec/lz4 v2.5.2+incompatible // indirect
 	github.com/segmentio/kafka-go v0.4.29
 	github.com/sirupsen/logrus v1.7.0
-	github.com/stretchr/testify v1.7.0
+	github.com/stretchr/testify v1.8.0
 	github.com/syndtr/goleveldb v1.0.0
 	github.com/tidwall/btree v1.1.0 // indirect
 	github.com/tidwall/buntdb v1.2.0
@@ -25,6 +25,7 @@ const (
 	traceRateLimitEnvVar  = "DD_APPSEC_TRACE_RATE_LIMIT"
 	obfuscatorKeyEnvVar   = "DD_APPSEC_OBFUSCATION_PARAMETER_KEY_REGEXP"
 	obfuscatorValueEnvVar = "DD_APPSEC_OBFUSCATION_PARAMETER_VALUE_REGEXP"
+	grpcMessagesEnvVar    = "DD_APPSEC_GRPC_MESSAGES_ENABLED"
 )
 
 const (
@@ -44,6 +45,8 @@ type config struct {
 	traceRateLimit uint
 	// Obfuscator configuration parameters
 	obfuscator ObfuscatorConfig
+	// Whether the messages received by gRPC servers are monitored, in addition to the RPC metadata.
+	grpcMessages bool
 }
 
 // ObfuscatorConfig wraps the key and value regexp to be passed to the WAF to perform obfuscation.
@@ -76,6 +79,7 @@ func newConfig() (*config, error) {
 		wafTimeout:     readWAFTimeoutConfig(),
 		traceRateLimit: readRateLimitConfig(),
 		obfuscator:     readObfuscatorConfig(),
+		grpcMessages:   readGRPCMessagesConfig(),
 	}, nil
 }
 
@@ -123,6 +127,20 @@ func readRateLimitConfig() (rate uint) {
 	return uint(parsed)
 }
 
+func readGRPCMessagesConfig() (enabled bool) {
+	enabled = true
+	value := os.Getenv(grpcMessagesEnvVar)
+	if value == "" {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		logEnvVarParsingError(grpcMessagesEnvVar, value, err, enabled)
+		return
+	}
+	return parsed
+}
+
 func readObfuscatorConfig() ObfuscatorConfig {
 	keyRE := readObfuscatorConfigRegexp(obfuscatorKeyEnvVar, defaultObfuscatorKeyRegex)
 	valueRE := readObfuscatorConfigRegexp(obfuscatorValueEnvVar, defaultObfuscatorValueRegex)
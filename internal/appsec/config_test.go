@@ -26,6 +26,7 @@ func TestConfig(t *testing.T) {
 			KeyRegex:   defaultObfuscatorKeyRegex,
 			ValueRegex: defaultObfuscatorValueRegex,
 		},
+		grpcMessages: true,
 	}
 
 	t.Run("default", func(t *testing.T) {
@@ -185,6 +186,28 @@ func TestConfig(t *testing.T) {
 		})
 	})
 
+	t.Run("grpc-messages", func(t *testing.T) {
+		t.Run("disabled", func(t *testing.T) {
+			expCfg := *expectedDefaultConfig
+			expCfg.grpcMessages = false
+			restoreEnv := cleanEnv()
+			defer restoreEnv()
+			require.NoError(t, os.Setenv(grpcMessagesEnvVar, "false"))
+			cfg, err := newConfig()
+			require.NoError(t, err)
+			require.Equal(t, &expCfg, cfg)
+		})
+
+		t.Run("not-parsable", func(t *testing.T) {
+			restoreEnv := cleanEnv()
+			defer restoreEnv()
+			require.NoError(t, os.Setenv(grpcMessagesEnvVar, "not a boolean"))
+			cfg, err := newConfig()
+			require.NoError(t, err)
+			require.Equal(t, expectedDefaultConfig, cfg)
+		})
+	})
+
 	t.Run("obfuscator", func(t *testing.T) {
 		t.Run("key-regexp", func(t *testing.T) {
 			t.Run("env-var-normal", func(t *testing.T) {
@@ -257,6 +280,7 @@ func cleanEnv() func() {
 		traceRateLimitEnvVar:  os.Getenv(traceRateLimitEnvVar),
 		obfuscatorKeyEnvVar:   os.Getenv(obfuscatorKeyEnvVar),
 		obfuscatorValueEnvVar: os.Getenv(obfuscatorValueEnvVar),
+		grpcMessagesEnvVar:    os.Getenv(grpcMessagesEnvVar),
 	}
 	for k, _ := range env {
 		if err := os.Unsetenv(k); err != nil {
@@ -12,6 +12,7 @@ package grpcsec
 import (
 	"encoding/json"
 	"reflect"
+	"sync/atomic"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/appsec/dyngo"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/appsec/dyngo/instrumentation"
@@ -39,6 +40,9 @@ type (
 		dyngo.Operation
 		instrumentation.TagsHolder
 		instrumentation.SecurityEventsHolder
+		// attackDetected is set to 1 as soon as an attack is reported during
+		// the operation lifetime.
+		attackDetected uint32
 	}
 	// HandlerOperationArgs is the grpc handler arguments.
 	HandlerOperationArgs struct {
@@ -88,6 +92,20 @@ func (op *HandlerOperation) Finish(res HandlerOperationRes) []json.RawMessage {
 	return op.Events()
 }
 
+// ReportAttack marks the handler operation as having received an attack. It
+// allows integrations to block the RPC as soon as an attack is detected, as
+// opposed to the security events that are only available once the operation
+// finishes.
+func (op *HandlerOperation) ReportAttack() {
+	atomic.StoreUint32(&op.attackDetected, 1)
+}
+
+// AttackDetected returns true when an attack was reported during the handler
+// operation lifetime so far.
+func (op *HandlerOperation) AttackDetected() bool {
+	return atomic.LoadUint32(&op.attackDetected) == 1
+}
+
 // gRPC handler operation's start and finish event callback function types.
 type (
 	// OnHandlerOperationStart function type, called when an gRPC handler
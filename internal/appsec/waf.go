@@ -38,7 +38,7 @@ const (
 )
 
 // Register the WAF event listener.
-func registerWAF(rules []byte, timeout time.Duration, limiter Limiter, obfCfg *ObfuscatorConfig) (unreg dyngo.UnregisterFunc, err error) {
+func registerWAF(rules []byte, timeout time.Duration, limiter Limiter, obfCfg *ObfuscatorConfig, grpcMessages bool) (unreg dyngo.UnregisterFunc, err error) {
 	// Check the WAF is healthy
 	if err := waf.Health(); err != nil {
 		return nil, err
@@ -77,7 +77,7 @@ func registerWAF(rules []byte, timeout time.Duration, limiter Limiter, obfCfg *O
 	}
 	if len(grpcAddresses) > 0 {
 		log.Debug("appsec: registering grpc waf listening to addresses %v", grpcAddresses)
-		unregisterGRPC = dyngo.Register(newGRPCWAFEventListener(waf, grpcAddresses, timeout, limiter))
+		unregisterGRPC = dyngo.Register(newGRPCWAFEventListener(waf, grpcAddresses, timeout, limiter, grpcMessages))
 	}
 
 	// Return an unregistration function that will also release the WAF instance.
@@ -169,9 +169,20 @@ func newHTTPWAFEventListener(handle *waf.Handle, addresses []string, timeout tim
 }
 
 // newGRPCWAFEventListener returns the WAF event listener to register in order
-// to enable it.
-func newGRPCWAFEventListener(handle *waf.Handle, _ []string, timeout time.Duration, limiter Limiter) dyngo.EventListener {
+// to enable it. The RPC metadata is evaluated once when the handler operation
+// starts. When monitorMessages is true, every received message is also evaluated,
+// along with the metadata, when its receive operation finishes.
+func newGRPCWAFEventListener(handle *waf.Handle, addresses []string, timeout time.Duration, limiter Limiter, monitorMessages bool) dyngo.EventListener {
 	var monitorRulesOnce sync.Once // per instantiation
+	var monitorMetadata, monitorMessage bool
+	for _, addr := range addresses {
+		switch addr {
+		case grpcServerRequestMetadata:
+			monitorMetadata = true
+		case grpcServerRequestMessage:
+			monitorMessage = monitorMessages
+		}
+	}
 
 	return grpcsec.OnHandlerOperationStart(func(op *grpcsec.HandlerOperation, handlerArgs grpcsec.HandlerOperationArgs) {
 		// Limit the maximum number of security events, as a streaming RPC could
@@ -188,10 +199,10 @@ func newGRPCWAFEventListener(handle *waf.Handle, _ []string, timeout time.Durati
 			mu     sync.Mutex // events mutex
 		)
 
-		op.On(grpcsec.OnReceiveOperationFinish(func(_ grpcsec.ReceiveOperation, res grpcsec.ReceiveOperationRes) {
+		run := func(values map[string]interface{}) {
 			if atomic.LoadUint32(&nbEvents) == maxWAFEventsPerRequest {
 				logOnce.Do(func() {
-					log.Debug("appsec: ignoring the rpc message due to the maximum number of security events per grpc call reached")
+					log.Debug("appsec: ignoring the rpc data due to the maximum number of security events per grpc call reached")
 				})
 				return
 			}
@@ -209,14 +220,6 @@ func newGRPCWAFEventListener(handle *waf.Handle, _ []string, timeout time.Durati
 				return
 			}
 			defer wafCtx.Close()
-			// Run the WAF on the rule addresses available in the args
-			// Note that we don't check if the address is present in the rules
-			// as we only support one at the moment, so this callback cannot be
-			// set when the address is not present.
-			values := map[string]interface{}{grpcServerRequestMessage: res.Message}
-			if md := handlerArgs.Metadata; len(md) > 0 {
-				values[grpcServerRequestMetadata] = md
-			}
 			event := runWAF(wafCtx, values, timeout)
 
 			// WAF run durations are WAF context bound. As of now we need to keep track of those externally since
@@ -231,11 +234,31 @@ func newGRPCWAFEventListener(handle *waf.Handle, _ []string, timeout time.Durati
 				return
 			}
 			log.Debug("appsec: attack detected by the grpc waf")
+			op.ReportAttack()
 			atomic.AddUint32(&nbEvents, 1)
 			mu.Lock()
 			events = append(events, event)
 			mu.Unlock()
-		}))
+		}
+
+		// Run the WAF on the metadata as soon as the RPC starts so that
+		// attacks can be detected before any message gets received.
+		if md := handlerArgs.Metadata; monitorMetadata && len(md) > 0 {
+			run(map[string]interface{}{grpcServerRequestMetadata: md})
+		}
+
+		if monitorMessage {
+			op.On(grpcsec.OnReceiveOperationFinish(func(_ grpcsec.ReceiveOperation, res grpcsec.ReceiveOperationRes) {
+				// The metadata is passed along with every message, as every run
+				// uses a new WAF context, so that the rules using both addresses
+				// can match.
+				values := map[string]interface{}{grpcServerRequestMessage: res.Message}
+				if md := handlerArgs.Metadata; monitorMetadata && len(md) > 0 {
+					values[grpcServerRequestMetadata] = md
+				}
+				run(values)
+			}))
+		}
 
 		op.On(grpcsec.OnHandlerOperationFinish(func(op *grpcsec.HandlerOperation, _ grpcsec.HandlerOperationRes) {
 			rInfo := handle.RulesetInfo()
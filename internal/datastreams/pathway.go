@@ -0,0 +1,140 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2022 Datadog, Inc.
+
+// Package datastreams implements the pathway propagation used by Data Streams
+// Monitoring to follow payloads across the services and queues they go through.
+package datastreams
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
+)
+
+// PropagationKey is the key used to propagate the encoded pathway in message
+// headers (e.g. Kafka).
+const PropagationKey = "dd-pathway-ctx"
+
+// Pathway is used to monitor how payloads are sent across different services.
+// A pathway is identified by a hash computed from all the checkpoints it went
+// through, and keeps track of the time at which it started and of the time at
+// which its last checkpoint was set.
+type Pathway struct {
+	hash         uint64
+	pathwayStart time.Time
+	edgeStart    time.Time
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// NewPathway creates a new pathway starting at the current service, with a
+// checkpoint identified by the given edge tags.
+func NewPathway(edgeTags ...string) Pathway {
+	t := now()
+	return Pathway{pathwayStart: t, edgeStart: t}.setCheckpoint(t, edgeTags)
+}
+
+// SetCheckpoint sets a checkpoint identified by the given edge tags on
+// the pathway, and returns the resulting pathway. p is not modified.
+func (p Pathway) SetCheckpoint(edgeTags ...string) Pathway {
+	return p.setCheckpoint(now(), edgeTags)
+}
+
+func (p Pathway) setCheckpoint(t time.Time, edgeTags []string) Pathway {
+	return Pathway{
+		hash:         pathwayHash(nodeHash(globalconfig.ServiceName(), globalconfig.Env(), edgeTags), p.hash),
+		pathwayStart: p.pathwayStart,
+		edgeStart:    t,
+	}
+}
+
+// GetHash returns the hash identifying the pathway.
+func (p Pathway) GetHash() uint64 { return p.hash }
+
+// PathwayStart returns the time at which the pathway started.
+func (p Pathway) PathwayStart() time.Time { return p.pathwayStart }
+
+// EdgeStart returns the time at which the last checkpoint was set.
+func (p Pathway) EdgeStart() time.Time { return p.edgeStart }
+
+// nodeHash returns the hash of a node of the pathway: the service along with the
+// edge tags it was reached from.
+func nodeHash(service, env string, edgeTags []string) uint64 {
+	tags := make([]string, len(edgeTags))
+	copy(tags, edgeTags)
+	sort.Strings(tags)
+	h := fnv.New64a()
+	h.Write([]byte(service))
+	h.Write([]byte(env))
+	for _, t := range tags {
+		h.Write([]byte(t))
+	}
+	return h.Sum64()
+}
+
+// pathwayHash combines the hash of a node with the hash of the pathway leading to it.
+func pathwayHash(nodeHash, parentHash uint64) uint64 {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b, nodeHash)
+	binary.LittleEndian.PutUint64(b[8:], parentHash)
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+// Encode encodes the pathway: the hash is encoded as 8 little endian bytes,
+// followed by the pathway and edge start times in milliseconds encoded as varints.
+func (p Pathway) Encode() []byte {
+	data := make([]byte, 8+2*binary.MaxVarintLen64)
+	binary.LittleEndian.PutUint64(data, p.hash)
+	n := 8
+	n += binary.PutVarint(data[n:], p.pathwayStart.UnixNano()/int64(time.Millisecond))
+	n += binary.PutVarint(data[n:], p.edgeStart.UnixNano()/int64(time.Millisecond))
+	return data[:n]
+}
+
+var errInvalidPathway = errors.New("datastreams: invalid encoded pathway")
+
+// Decode decodes a pathway encoded with Encode.
+func Decode(data []byte) (p Pathway, err error) {
+	if len(data) < 8 {
+		return p, errInvalidPathway
+	}
+	p.hash = binary.LittleEndian.Uint64(data)
+	data = data[8:]
+	pathwayStart, n := binary.Varint(data)
+	if n <= 0 {
+		return p, errInvalidPathway
+	}
+	edgeStart, m := binary.Varint(data[n:])
+	if m <= 0 {
+		return p, errInvalidPathway
+	}
+	p.pathwayStart = time.Unix(0, pathwayStart*int64(time.Millisecond))
+	p.edgeStart = time.Unix(0, edgeStart*int64(time.Millisecond))
+	return p, nil
+}
+
+type contextKey struct{}
+
+// ContextWithPathway returns a copy of ctx holding the given pathway.
+func ContextWithPathway(ctx context.Context, p Pathway) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// PathwayFromContext returns the pathway held by ctx, if any.
+func PathwayFromContext(ctx context.Context) (p Pathway, ok bool) {
+	if ctx == nil {
+		return p, false
+	}
+	p, ok = ctx.Value(contextKey{}).(Pathway)
+	return p, ok
+}
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2022 Datadog, Inc.
+
+package datastreams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/globalconfig"
+)
+
+func TestPathway(t *testing.T) {
+	defer func(old func() time.Time) { now = old }(now)
+	start := time.Unix(1640000000, 0)
+	now = func() time.Time { return start }
+
+	t.Run("checkpoints", func(t *testing.T) {
+		p := NewPathway("direction:out", "topic:topic1", "type:kafka")
+		assert.Equal(t, start, p.PathwayStart())
+		assert.Equal(t, start, p.EdgeStart())
+		assert.NotZero(t, p.GetHash())
+
+		now = func() time.Time { return start.Add(time.Second) }
+		next := p.SetCheckpoint("direction:in", "topic:topic1", "type:kafka")
+		assert.Equal(t, start, next.PathwayStart())
+		assert.Equal(t, start.Add(time.Second), next.EdgeStart())
+		assert.NotEqual(t, p.GetHash(), next.GetHash())
+		// p is not modified
+		assert.Equal(t, start, p.EdgeStart())
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		// the order of the edge tags doesn't matter
+		p1 := NewPathway("type:kafka", "topic:topic1")
+		p2 := NewPathway("topic:topic1", "type:kafka")
+		assert.Equal(t, p1.GetHash(), p2.GetHash())
+		// the parent pathway matters
+		assert.NotEqual(t, p1.GetHash(), p1.SetCheckpoint("type:kafka", "topic:topic1").GetHash())
+		// so do the service and the environment
+		defer globalconfig.SetServiceName("")
+		defer globalconfig.SetEnv("")
+		globalconfig.SetServiceName("service-a")
+		p3 := NewPathway("type:kafka", "topic:topic1")
+		assert.NotEqual(t, p1.GetHash(), p3.GetHash())
+		globalconfig.SetEnv("env-a")
+		assert.NotEqual(t, p3.GetHash(), NewPathway("type:kafka", "topic:topic1").GetHash())
+	})
+
+	t.Run("encoding", func(t *testing.T) {
+		p := NewPathway("type:kafka").SetCheckpoint("type:kafka")
+		decoded, err := Decode(p.Encode())
+		require.NoError(t, err)
+		assert.Equal(t, p.GetHash(), decoded.GetHash())
+		assert.True(t, p.PathwayStart().Equal(decoded.PathwayStart()))
+		assert.True(t, p.EdgeStart().Equal(decoded.EdgeStart()))
+
+		for _, data := range [][]byte{nil, {1, 2, 3}, p.Encode()[:8]} {
+			_, err := Decode(data)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		_, ok := PathwayFromContext(context.Background())
+		assert.False(t, ok)
+		p := NewPathway("type:kafka")
+		got, ok := PathwayFromContext(ContextWithPathway(context.Background(), p))
+		assert.True(t, ok)
+		assert.Equal(t, p, got)
+	})
+}
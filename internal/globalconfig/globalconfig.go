@@ -23,6 +23,7 @@ type config struct {
 	mu            sync.RWMutex
 	analyticsRate float64
 	serviceName   string
+	env           string
 	runtimeID     string
 }
 
@@ -56,6 +57,20 @@ func SetServiceName(name string) {
 	cfg.serviceName = name
 }
 
+// Env returns the environment set for this application.
+func Env() string {
+	cfg.mu.RLock()
+	defer cfg.mu.RUnlock()
+	return cfg.env
+}
+
+// SetEnv sets the global environment set for this application.
+func SetEnv(env string) {
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+	cfg.env = env
+}
+
 // RuntimeID returns this process's unique runtime id.
 func RuntimeID() string {
 	cfg.mu.RLock()
@@ -6,6 +6,8 @@
 // Package traceprof contains shared logic for cross-cutting tracer/profiler features.
 package traceprof
 
+import "sync/atomic"
+
 // pprof labels applied by the tracer to show up in the profiler's profiles.
 const (
 	SpanID          = "span id"
@@ -18,3 +20,22 @@ const (
 	EndpointEnvVar     = "DD_PROFILING_ENDPOINT_COLLECTION_ENABLED"
 	CodeHotspotsEnvVar = "DD_PROFILING_CODE_HOTSPOTS_COLLECTION_ENABLED"
 )
+
+// profilerEnabled is set to 1 while the profiler is running and 0 otherwise.
+var profilerEnabled uint32
+
+// SetProfilerEnabled is called by the profiler when it starts or stops so the
+// tracer can tell the backend which traces may have profiles associated with
+// them.
+func SetProfilerEnabled(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreUint32(&profilerEnabled, v)
+}
+
+// IsProfilerEnabled returns true if the profiler is currently running.
+func IsProfilerEnabled() bool {
+	return atomic.LoadUint32(&profilerEnabled) == 1
+}
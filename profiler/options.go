@@ -339,6 +339,11 @@ func WithAgentlessUpload() Option {
 // is true. This option takes precedence over the DD_PROFILING_DELTA
 // environment variable that can be set to "true" or "false" as well. See
 // https://dtdg.co/go-delta-profile-docs for more information.
+//
+// Delta profiles are computed for the block and mutex profiles, as well as for
+// the allocation sample types (alloc_objects, alloc_space) of the heap
+// profile, which is where the Go runtime reports the data of its "allocs"
+// profile. The in-use sample types are snapshots and are uploaded as-is.
 func WithDeltaProfiles(enabled bool) Option {
 	return func(cfg *config) {
 		cfg.deltaProfiles = enabled
@@ -133,6 +133,16 @@ func TestOptions(t *testing.T) {
 		assert.Len(t, cfg.types, 2)
 	})
 
+	t.Run("WithProfileTypes/GoroutineWaitProfile", func(t *testing.T) {
+		var cfg config
+		WithProfileTypes(GoroutineWaitProfile)(&cfg)
+		_, ok := cfg.types[GoroutineWaitProfile]
+		assert.True(t, ok)
+		assert.Equal(t, "goroutinewait", GoroutineWaitProfile.String())
+		p := &profiler{cfg: &cfg}
+		assert.Contains(t, p.enabledProfileTypes(), GoroutineWaitProfile)
+	})
+
 	t.Run("WithService", func(t *testing.T) {
 		var cfg config
 		WithService("serviceName")(&cfg)
@@ -40,11 +40,16 @@ const (
 	MutexProfile
 	// GoroutineProfile reports stack traces of all current goroutines
 	GoroutineProfile
-	// expGoroutineWaitProfile reports stack traces and wait durations for
+	// GoroutineWaitProfile reports stack traces and wait durations for
 	// goroutines that have been waiting or blocked by a syscall for > 1 minute
-	// since the last GC. This feature is currently experimental and only
-	// available within DD by setting the DD_PROFILING_WAIT_PROFILE env variable.
-	expGoroutineWaitProfile
+	// since the last GC, labeled by their wait state (e.g. "chan receive",
+	// "semacquire" or "IO wait"). It is produced from a full goroutine stack
+	// dump, which stops the world, so it is skipped when the number of
+	// goroutines exceeds DD_PROFILING_WAIT_PROFILE_MAX_GOROUTINES (1000 by
+	// default). This profile type is experimental and not enabled by default;
+	// it can be enabled with WithProfileTypes or by setting the
+	// DD_PROFILING_WAIT_PROFILE env variable.
+	GoroutineWaitProfile
 	// MetricsProfile reports top-line metrics associated with user-specified profiles
 	MetricsProfile
 )
@@ -125,7 +130,7 @@ var profileTypes = map[ProfileType]profileType{
 		Filename: "goroutines.pprof",
 		Collect:  collectGenericProfile("goroutine", nil),
 	},
-	expGoroutineWaitProfile: {
+	GoroutineWaitProfile: {
 		Name:     "goroutinewait",
 		Filename: "goroutineswait.pprof",
 		Collect: func(p *profiler) ([]byte, error) {
@@ -232,7 +232,7 @@ main.main()
 		}
 
 		require.NoError(t, err)
-		profs, err := p.runProfile(expGoroutineWaitProfile)
+		profs, err := p.runProfile(GoroutineWaitProfile)
 		require.NoError(t, err)
 		require.Equal(t, "goroutineswait.pprof", profs[0].name)
 
@@ -315,7 +315,7 @@ main.main()
 			return err
 		}
 		require.NoError(t, err)
-		_, err = p.runProfile(expGoroutineWaitProfile)
+		_, err = p.runProfile(GoroutineWaitProfile)
 		var errRoutines, errLimit int
 		msg := "skipping goroutines wait profile: %d goroutines exceeds DD_PROFILING_WAIT_PROFILE_MAX_GOROUTINES limit of %d"
 		fmt.Sscanf(err.Error(), msg, &errRoutines, &errLimit)
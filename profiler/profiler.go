@@ -19,6 +19,7 @@ import (
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal"
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/traceprof"
 
 	pprofile "github.com/google/pprof/profile"
 )
@@ -39,6 +40,7 @@ func Start(opts ...Option) error {
 	defer mu.Unlock()
 	if activeProfiler != nil {
 		activeProfiler.stop()
+		traceprof.SetProfilerEnabled(false)
 	}
 	p, err := newProfiler(opts...)
 	if err != nil {
@@ -46,6 +48,7 @@ func Start(opts ...Option) error {
 	}
 	activeProfiler = p
 	activeProfiler.run()
+	traceprof.SetProfilerEnabled(true)
 	return nil
 }
 
@@ -56,6 +59,7 @@ func Stop() {
 	if activeProfiler != nil {
 		activeProfiler.stop()
 		activeProfiler = nil
+		traceprof.SetProfilerEnabled(false)
 	}
 	mu.Unlock()
 }
@@ -120,9 +124,8 @@ func newProfiler(opts ...Option) (*profiler, error) {
 	for _, opt := range opts {
 		opt(cfg)
 	}
-	// TODO(fg) remove this after making expGoroutineWaitProfile public.
 	if os.Getenv("DD_PROFILING_WAIT_PROFILE") != "" {
-		cfg.addProfileType(expGoroutineWaitProfile)
+		cfg.addProfileType(GoroutineWaitProfile)
 	}
 	// Agentless upload is disabled by default as of v1.30.0, but
 	// WithAgentlessUpload can be used to enable it for testing and debugging.
@@ -275,7 +278,7 @@ func (p *profiler) enabledProfileTypes() []ProfileType {
 		BlockProfile,
 		MutexProfile,
 		GoroutineProfile,
-		expGoroutineWaitProfile,
+		GoroutineWaitProfile,
 		MetricsProfile,
 	}
 	enabled := []ProfileType{}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"gopkg.in/DataDog/dd-trace-go.v1/internal/log"
+	"gopkg.in/DataDog/dd-trace-go.v1/internal/traceprof"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,6 +73,15 @@ func TestStart(t *testing.T) {
 		mu.Unlock()
 	})
 
+	t.Run("traceprof", func(t *testing.T) {
+		if err := Start(WithLogStartup(false)); err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, traceprof.IsProfilerEnabled())
+		Stop()
+		assert.False(t, traceprof.IsProfilerEnabled())
+	})
+
 	t.Run("options", func(t *testing.T) {
 		if err := Start(); err != nil {
 			t.Fatal(err)